@@ -75,6 +75,13 @@ Create the name of the rbac cluster role binding
 {{- end -}}
 {{- end -}}
 
+{{/*
+Create the name of the role and role binding granting access to the credentials Secret
+*/}}
+{{- define "rafterUploadService.credentialsRoleName" -}}
+{{- printf "%s-credentials" (include "rafterUploadService.fullname" .) | trunc 63 | trimSuffix "-" -}}
+{{- end -}}
+
 {{/*
 Create the name of the service monitor
 */}}
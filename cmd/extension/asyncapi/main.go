@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-project/rafter/pkg/runtime/service"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -18,8 +19,9 @@ var (
 )
 
 type config struct {
-	Verbose bool `envconfig:"default=false"`
-	Service service.Config
+	Verbose        bool `envconfig:"default=false"`
+	Service        service.Config
+	Authentication service.AuthenticationConfig
 }
 
 func main() {
@@ -35,7 +37,11 @@ func main() {
 	defer cancel()
 	signal.CancelOnInterrupt(ctx, cancel, stopCh)
 
-	srv := service.New(cfg.Service)
+	verifiers, err := newVerifiers(cfg.Authentication)
+	if err != nil {
+		log.Fatal(errors.Wrap(err, "while initializing authentication"))
+	}
+	srv := service.New(cfg.Service, verifiers...)
 
 	log.Info("Registering endpoints")
 	if err := asyncapi.AddToService(srv); err != nil {
@@ -47,6 +53,23 @@ func main() {
 	}
 }
 
+func newVerifiers(cfg service.AuthenticationConfig) ([]service.Verifier, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading Kubernetes client configuration")
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating Kubernetes client")
+	}
+
+	return service.NewVerifiers(cfg, clientset.AuthenticationV1().TokenReviews())
+}
+
 func loadConfig(prefix string) (config, error) {
 	cfg := config{}
 	err := envconfig.InitWithPrefix(&cfg, prefix)
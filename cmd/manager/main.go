@@ -5,21 +5,25 @@ import (
 	"net/http"
 	"os"
 
-	"github.com/minio/minio-go"
 	"github.com/vrischmann/envconfig"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	// +kubebuilder:scaffold:imports
 
 	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/cloudevents"
 	"github.com/kyma-project/rafter/internal/controllers"
+	"github.com/kyma-project/rafter/internal/gc"
 	"github.com/kyma-project/rafter/internal/loader"
 	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/storeclass"
+	"github.com/kyma-project/rafter/internal/storeclient"
 	"github.com/kyma-project/rafter/internal/webhookconfig"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 )
@@ -47,6 +51,8 @@ type Config struct {
 	AssetGroup          controllers.AssetGroupConfig
 	ClusterAssetGroup   controllers.ClusterAssetGroupConfig
 	WebhookConfigMap    webhookconfig.Config
+	GC                  gc.Config
+	CloudEvents         cloudevents.Config
 	BucketRegion        string `envconfig:"optional"`
 	ClusterBucketRegion string `envconfig:"optional"`
 }
@@ -68,13 +74,33 @@ func main() {
 	}
 
 	httpClient := &http.Client{}
-	minioClient, err := minio.New(cfg.Store.Endpoint, cfg.Store.AccessKey, cfg.Store.SecretKey, cfg.Store.UseSSL)
+
+	bucketNameTemplate, err := store.ParseBucketNameTemplate(cfg.Store.BucketNameTemplate)
 	if err != nil {
-		setupLog.Error(err, "unable initialize Minio client")
+		setupLog.Error(err, "unable to parse bucket name template")
 		os.Exit(1)
 	}
 
 	restConfig := ctrl.GetConfigOrDie()
+	coreClient, err := corev1.NewForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize core client")
+		os.Exit(1)
+	}
+
+	credentials := storeclient.Credentials{AccessKey: cfg.Store.AccessKey, SecretKey: cfg.Store.SecretKey}
+	credentialsSource, err := storeclient.NewSource(cfg.Store.Credentials, credentials, coreClient)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize store credentials")
+		os.Exit(1)
+	}
+
+	minioClient, err := storeclient.New(credentialsSource, storeclient.NewMinioFactory(cfg.Store.Endpoint, cfg.Store.UseSSL))
+	if err != nil {
+		setupLog.Error(err, "unable initialize Minio client")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
@@ -92,22 +118,51 @@ func main() {
 		os.Exit(1)
 	}
 
-	container := &controllers.Container{
-		Manager:   mgr,
-		Store:     store.New(minioClient, cfg.Store.UploadWorkersCount),
-		Loader:    loader.New(dynamicClient, cfg.Loader.TemporaryDirectory, cfg.Loader.VerifySSL),
-		Validator: assethook.NewValidator(httpClient, cfg.Webhook.ValidationTimeout, cfg.Webhook.ValidationWorkersCount),
-		Mutator:   assethook.NewMutator(httpClient, cfg.Webhook.MutationTimeout, cfg.Webhook.MutationWorkersCount),
-		Extractor: assethook.NewMetadataExtractor(httpClient, cfg.Webhook.MetadataExtractionTimeout),
+	newStore := func(client store.MinioClient) store.Store {
+		return store.New(client, cfg.Store.UploadWorkersCount, bucketNameTemplate)
 	}
 
 	webhookSvc := initWebhookConfigService(cfg.WebhookConfigMap, dynamicClient)
+	authenticator, err := assethook.NewAuthenticator(cfg.Webhook.Authentication, ctrl.Log.WithName("webhook-authentication"), webhookSvc)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize webhook authentication")
+		os.Exit(1)
+	}
+
+	container := &controllers.Container{
+		Manager:      mgr,
+		Store:        newStore(minioClient),
+		StoreClasses: storeclass.New(mgr.GetClient(), storeclass.NewMinioClient, newStore),
+		Loader:       loader.New(dynamicClient, cfg.Loader.TemporaryDirectory, cfg.Loader.VerifySSL),
+		Validator:    assethook.NewValidator(httpClient, cfg.Webhook.ValidationTimeout, cfg.Webhook.ValidationWorkersCount, cfg.Webhook.Retry, authenticator),
+		Mutator:      assethook.NewMutator(httpClient, cfg.Webhook.MutationTimeout, cfg.Webhook.MutationWorkersCount, cfg.Webhook.Retry, authenticator),
+		Extractor:    assethook.NewMetadataExtractor(httpClient, cfg.Webhook.MetadataExtractionTimeout, cfg.Webhook.Retry, authenticator),
+		Notifier:     assethook.NewNotifier(httpClient, cfg.Webhook.NotificationTimeout, cfg.Webhook.Retry, authenticator),
+	}
+
+	if cfg.CloudEvents.Enabled {
+		publisher, err := cloudevents.New(cfg.CloudEvents, ctrl.Log.WithName("cloudevents"), httpClient)
+		if err != nil {
+			setupLog.Error(err, "unable to create cloud events publisher")
+			os.Exit(1)
+		}
+		if err := mgr.Add(publisher); err != nil {
+			setupLog.Error(err, "unable to add cloud events publisher")
+			os.Exit(1)
+		}
+		container.Events = publisher
+	}
 
 	if err = controllers.NewClusterAsset(cfg.ClusterAsset, ctrl.Log.WithName("controllers").WithName("ClusterAsset"), container).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterAsset")
 		os.Exit(1)
 	}
-	if err = controllers.NewClusterBucket(cfg.ClusterBucket, ctrl.Log.WithName("controllers").WithName("ClusterBucket"), container).SetupWithManager(mgr); err != nil {
+	clusterBucketReconciler, err := controllers.NewClusterBucket(cfg.ClusterBucket, ctrl.Log.WithName("controllers").WithName("ClusterBucket"), container)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterBucket")
+		os.Exit(1)
+	}
+	if err = clusterBucketReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterBucket")
 		os.Exit(1)
 	}
@@ -115,22 +170,50 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Asset")
 		os.Exit(1)
 	}
-	if err = controllers.NewBucket(cfg.Bucket, ctrl.Log.WithName("controllers").WithName("Bucket"), container).SetupWithManager(mgr); err != nil {
+	bucketReconciler, err := controllers.NewBucket(cfg.Bucket, ctrl.Log.WithName("controllers").WithName("Bucket"), container)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Bucket")
+		os.Exit(1)
+	}
+	if err = bucketReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Bucket")
 		os.Exit(1)
 	}
-	if err = controllers.NewClusterAssetGroup(cfg.ClusterAssetGroup, ctrl.Log.WithName("controllers").WithName("ClusterAssetGroup"), mgr, webhookSvc).SetupWithManager(mgr); err != nil {
+	if err = controllers.NewClusterAssetGroup(cfg.ClusterAssetGroup, ctrl.Log.WithName("controllers").WithName("ClusterAssetGroup"), mgr, webhookSvc, container.Events).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterAssetGroup")
 		os.Exit(1)
 	}
-	if err = controllers.NewAssetGroup(cfg.AssetGroup, ctrl.Log.WithName("controllers").WithName("AssetGroup"), mgr, webhookSvc).SetupWithManager(mgr); err != nil {
+	if err = controllers.NewAssetGroup(cfg.AssetGroup, ctrl.Log.WithName("controllers").WithName("AssetGroup"), mgr, webhookSvc, container.Events).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AssetGroup")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
 
+	if cfg.GC.Enabled {
+		collector, err := gc.New(cfg.GC, ctrl.Log.WithName("gc"), mgr.GetClient(), container.Store)
+		if err != nil {
+			setupLog.Error(err, "unable to create garbage collector")
+			os.Exit(1)
+		}
+		if err := mgr.Add(collector); err != nil {
+			setupLog.Error(err, "unable to add garbage collector")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	stopCh := ctrl.SetupSignalHandler()
+	go minioClient.Run(stopCh, cfg.Store.Credentials.RefreshInterval, func(refreshed bool, err error) {
+		if err != nil {
+			setupLog.Error(err, "unable to refresh store credentials")
+			return
+		}
+		if refreshed {
+			setupLog.Info("store credentials refreshed")
+		}
+	})
+
+	if err := mgr.Start(stopCh); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
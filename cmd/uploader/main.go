@@ -11,8 +11,8 @@ import (
 	"github.com/kyma-project/rafter/internal/bucket"
 	"github.com/kyma-project/rafter/internal/configurer"
 	"github.com/kyma-project/rafter/internal/requesthandler"
+	"github.com/kyma-project/rafter/internal/storeclient"
 	"github.com/kyma-project/rafter/pkg/runtime/signal"
-	"github.com/minio/minio-go"
 	"github.com/pkg/errors"
 	"github.com/vrischmann/envconfig"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -31,8 +31,9 @@ type config struct {
 		ExternalEndpoint string `envconfig:"optional"`
 		Port             int    `envconfig:"default=443"`
 		Secure           bool   `envconfig:"default=true"`
-		AccessKey        string
-		SecretKey        string
+		AccessKey        string `envconfig:"optional"`
+		SecretKey        string `envconfig:"optional"`
+		Credentials      storeclient.CredentialsConfig
 	}
 	Bucket           bucket.Config
 	MaxUploadWorkers int           `envconfig:"default=10"`
@@ -53,13 +54,27 @@ func main() {
 
 	uploadEndpoint := fmt.Sprintf("%s:%d", cfg.Upload.Endpoint, cfg.Upload.Port)
 
-	client, err := minio.New(uploadEndpoint, cfg.Upload.AccessKey, cfg.Upload.SecretKey, cfg.Upload.Secure)
-	exitOnError(err, "Error during upload client initialization")
-
 	k8sConfig, err := newRestClientConfig(cfg.KubeconfigPath)
 	exitOnError(err, "Error while initializing REST client config")
 	k8sCoreCli, err := corev1.NewForConfig(k8sConfig)
 	exitOnError(err, "Error during K8s Core client initialization")
+
+	credentials := storeclient.Credentials{AccessKey: cfg.Upload.AccessKey, SecretKey: cfg.Upload.SecretKey}
+	credentialsSource, err := storeclient.NewSource(cfg.Upload.Credentials, credentials, k8sCoreCli)
+	exitOnError(err, "Error during upload credentials initialization")
+
+	client, err := storeclient.New(credentialsSource, storeclient.NewMinioFactory(uploadEndpoint, cfg.Upload.Secure))
+	exitOnError(err, "Error during upload client initialization")
+
+	go client.Run(stopCh, cfg.Upload.Credentials.RefreshInterval, func(refreshed bool, err error) {
+		if err != nil {
+			glog.Error(errors.Wrap(err, "while refreshing upload credentials"))
+			return
+		}
+		if refreshed {
+			glog.Info("Upload credentials refreshed")
+		}
+	})
 	c := configurer.New(k8sCoreCli, cfg.ConfigMap)
 	exitOnError(err, "Error during configurer creation")
 
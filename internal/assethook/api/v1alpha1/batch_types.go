@@ -0,0 +1,17 @@
+package v1alpha1
+
+// BatchField is the form field marking a batch request. Files of a batch request are sent in parts named by their paths.
+const BatchField = "batch"
+
+// BatchResult stores the result of processing a single file of a batch request
+type BatchResult struct {
+	FilePath string `json:"filePath"`
+	Success  bool   `json:"success"`
+	Modified bool   `json:"modified,omitempty"`
+	Content  []byte `json:"content,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results,omitempty"`
+}
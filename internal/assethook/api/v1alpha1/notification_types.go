@@ -0,0 +1,30 @@
+package v1alpha1
+
+import "encoding/json"
+
+// NotificationEvent describes what happened to the asset content
+type NotificationEvent string
+
+const (
+	NotificationUploaded NotificationEvent = "Uploaded"
+	NotificationDeleted  NotificationEvent = "Deleted"
+)
+
+// NotificationFile stores data of a single published file
+type NotificationFile struct {
+	Name     string           `json:"name"`
+	URL      string           `json:"url,omitempty"`
+	Sha256   string           `json:"sha256,omitempty"`
+	Size     int64            `json:"size,omitempty"`
+	Metadata *json.RawMessage `json:"metadata,omitempty"`
+}
+
+// NotificationRequest is sent to notification webhooks. Namespace is empty for cluster-wide assets.
+type NotificationRequest struct {
+	Event      NotificationEvent  `json:"event"`
+	Namespace  string             `json:"namespace,omitempty"`
+	Name       string             `json:"name"`
+	Generation int64              `json:"generation"`
+	BaseURL    string             `json:"baseUrl,omitempty"`
+	Files      []NotificationFile `json:"files,omitempty"`
+}
@@ -0,0 +1,143 @@
+package assethook
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/rafter/internal/webhookconfig"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+type AuthenticationConfig struct {
+	ClientCertificateFile string `envconfig:"optional"`
+	ClientKeyFile         string `envconfig:"optional"`
+	TokenFile             string `envconfig:"optional"`
+}
+
+// Authenticator provides the identity of the manager to webhooks. Files are read on every use, so rotated credentials are picked up.
+// The ServiceAccount token is sent only to Services of webhooks configured by cluster administrators in the webhook ConfigMap,
+// as Asset authors could otherwise point a webhook at their own Service and collect the token. It is never sent without TLS.
+type Authenticator struct {
+	cfg           AuthenticationConfig
+	log           logr.Logger
+	webhookConfig webhookconfig.AssetWebhookConfigService
+}
+
+func NewAuthenticator(cfg AuthenticationConfig, log logr.Logger, webhookConfig webhookconfig.AssetWebhookConfigService) (*Authenticator, error) {
+	if (cfg.ClientCertificateFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, errors.New("both client certificate and key files must be set")
+	}
+	if cfg.ClientCertificateFile != "" {
+		if _, err := tls.LoadX509KeyPair(cfg.ClientCertificateFile, cfg.ClientKeyFile); err != nil {
+			return nil, errors.Wrap(err, "while loading client certificate")
+		}
+	}
+	if cfg.TokenFile != "" {
+		if _, err := ioutil.ReadFile(cfg.TokenFile); err != nil {
+			return nil, errors.Wrap(err, "while reading token")
+		}
+	}
+
+	return &Authenticator{cfg: cfg, log: log, webhookConfig: webhookConfig}, nil
+}
+
+func (a *Authenticator) usesClientCertificate(service v1beta1.WebhookService) (bool, error) {
+	configured := a != nil && a.cfg.ClientCertificateFile != ""
+	if service.Authentication == nil || service.Authentication.ClientCertificate == nil {
+		return configured, nil
+	}
+	if *service.Authentication.ClientCertificate && !configured {
+		return false, errors.New("client certificate of the manager is not configured")
+	}
+
+	return *service.Authentication.ClientCertificate, nil
+}
+
+func (a *Authenticator) usesToken(ctx context.Context, service v1beta1.WebhookService) (bool, error) {
+	requested := isTokenRequested(service)
+	if requested && !*service.Authentication.ServiceAccountToken {
+		return false, nil
+	}
+	if requested && service.URL != "" {
+		return false, errors.New("ServiceAccount token can't be sent to a webhook URL")
+	}
+
+	configured := a != nil && a.cfg.TokenFile != ""
+	if !configured {
+		if requested {
+			return false, errors.New("ServiceAccount token of the manager is not configured")
+		}
+		return false, nil
+	}
+
+	trusted, err := a.isConfiguredByAdmin(ctx, service)
+	if err != nil {
+		return false, err
+	}
+	if requested && !trusted {
+		return false, errors.New("ServiceAccount token is sent only to webhooks configured in the webhook ConfigMap")
+	}
+
+	return trusted, nil
+}
+
+func isTokenRequested(service v1beta1.WebhookService) bool {
+	return service.Authentication != nil && service.Authentication.ServiceAccountToken != nil
+}
+
+// isConfiguredByAdmin checks if the Service of the webhook is referenced in the webhook ConfigMap
+func (a *Authenticator) isConfiguredByAdmin(ctx context.Context, service v1beta1.WebhookService) (bool, error) {
+	if service.URL != "" || a.webhookConfig == nil {
+		return false, nil
+	}
+
+	config, err := a.webhookConfig.Get(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "while getting webhook configuration")
+	}
+	for _, sourceConfig := range config {
+		for _, configured := range sourceConfig.Services() {
+			if configured.URL == "" && configured.Name == service.Name && configured.Namespace == service.Namespace {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (a *Authenticator) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	certificate, err := tls.LoadX509KeyPair(a.cfg.ClientCertificateFile, a.cfg.ClientKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "while loading client certificate")
+	}
+
+	return &certificate, nil
+}
+
+func (a *Authenticator) authorize(ctx context.Context, req *http.Request, service v1beta1.WebhookService) error {
+	useToken, err := a.usesToken(ctx, service)
+	if err != nil || !useToken {
+		return err
+	}
+	if req.URL.Scheme != string(v1beta1.WebhookSchemeHTTPS) {
+		if isTokenRequested(service) {
+			return errors.Errorf("ServiceAccount token can't be sent over %s", req.URL.Scheme)
+		}
+		a.log.Info("ServiceAccount token not sent to a webhook without TLS", "namespace", service.Namespace, "name", service.Name, "scheme", req.URL.Scheme)
+		return nil
+	}
+
+	token, err := ioutil.ReadFile(a.cfg.TokenFile)
+	if err != nil {
+		return errors.Wrap(err, "while reading token")
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package assethook_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/assethook/automock"
+	"github.com/kyma-project/rafter/internal/webhookconfig"
+	amcfg "github.com/kyma-project/rafter/internal/webhookconfig/automock"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestAuthenticator_Token(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assethook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("test-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	enabled, disabled := true, false
+
+	webhookConfig := webhookconfig.AssetWebhookConfigMap{
+		"markdown": {
+			Validations:   []webhookconfig.AssetWebhookService{{WebhookService: webhookconfig.WebhookService{Name: "validator", Namespace: "kyma-system"}}},
+			Notifications: []webhookconfig.WebhookService{{Name: "notifier", Namespace: "kyma-system"}},
+		},
+	}
+
+	for testName, testCase := range map[string]struct {
+		service  v1beta1.WebhookService
+		expected string
+	}{
+		"AdminServiceDefault": {
+			service:  v1beta1.WebhookService{Name: "notifier", Namespace: "kyma-system", Scheme: v1beta1.WebhookSchemeHTTPS},
+			expected: "Bearer test-token",
+		},
+		"AdminValidationService": {
+			service:  v1beta1.WebhookService{Name: "validator", Namespace: "kyma-system", Scheme: v1beta1.WebhookSchemeHTTPS},
+			expected: "Bearer test-token",
+		},
+		"AdminServiceHTTP": {
+			service: v1beta1.WebhookService{Name: "notifier", Namespace: "kyma-system"},
+		},
+		"AdminServiceDisabled": {
+			service: v1beta1.WebhookService{Name: "notifier", Namespace: "kyma-system", Authentication: &v1beta1.WebhookAuthentication{ServiceAccountToken: &disabled}},
+		},
+		"ServiceDefault": {
+			service: v1beta1.WebhookService{Name: "test", Namespace: "test"},
+		},
+		"URLDefault": {
+			service: v1beta1.WebhookService{URL: "https://webhooks.example.com/notify"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			webhookConfigSvc := new(amcfg.AssetWebhookConfigService)
+			webhookConfigSvc.On("Get", context.TODO()).Return(webhookConfig, nil).Maybe()
+			authenticator, err := assethook.NewAuthenticator(assethook.AuthenticationConfig{TokenFile: tokenFile}, logf.Log, webhookConfigSvc)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+
+			client := new(automock.HttpClient)
+			defer client.AssertExpectations(t)
+			client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Header.Get("Authorization") == testCase.expected
+			})).Return(fixHttpResponse(http.StatusOK, ""), nil).Once()
+
+			notifier := assethook.NewNotifier(client, time.Minute, assethook.RetryConfig{}, authenticator)
+
+			// When
+			err = notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{testCase.service})
+
+			// Then
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+	}
+
+	for testName, service := range map[string]v1beta1.WebhookService{
+		"ServiceEnabled":   {Name: "test", Namespace: "test", Authentication: &v1beta1.WebhookAuthentication{ServiceAccountToken: &enabled}},
+		"URLEnabled":       {URL: "https://webhooks.example.com/notify", Authentication: &v1beta1.WebhookAuthentication{ServiceAccountToken: &enabled}},
+		"AdminHTTPEnabled": {Name: "notifier", Namespace: "kyma-system", Authentication: &v1beta1.WebhookAuthentication{ServiceAccountToken: &enabled}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			webhookConfigSvc := new(amcfg.AssetWebhookConfigService)
+			webhookConfigSvc.On("Get", context.TODO()).Return(webhookConfig, nil).Maybe()
+			authenticator, err := assethook.NewAuthenticator(assethook.AuthenticationConfig{TokenFile: tokenFile}, logf.Log, webhookConfigSvc)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			notifier := assethook.NewNotifier(new(automock.HttpClient), time.Minute, assethook.RetryConfig{}, authenticator)
+
+			// When
+			err = notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+			// Then
+			g.Expect(err).To(gomega.HaveOccurred())
+		})
+	}
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		service := v1beta1.WebhookService{Name: "test", Namespace: "test", Authentication: &v1beta1.WebhookAuthentication{ServiceAccountToken: &enabled}}
+		notifier := assethook.NewNotifier(new(automock.HttpClient), time.Minute, assethook.RetryConfig{}, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestAuthenticator_ClientCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assethook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile, clientCAs := fixClientCertificate(t, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	disabled := false
+
+	t.Run("Sent", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		authenticator, err := assethook.NewAuthenticator(assethook.AuthenticationConfig{ClientCertificateFile: certFile, ClientKeyFile: keyFile}, logf.Log, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		service := v1beta1.WebhookService{URL: server.URL, CABundle: caBundle}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, assethook.RetryConfig{}, authenticator)
+
+		// When
+		err = notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		authenticator, err := assethook.NewAuthenticator(assethook.AuthenticationConfig{ClientCertificateFile: certFile, ClientKeyFile: keyFile}, logf.Log, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		service := v1beta1.WebhookService{URL: server.URL, CABundle: caBundle, Authentication: &v1beta1.WebhookAuthentication{ClientCertificate: &disabled}}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, assethook.RetryConfig{}, authenticator)
+
+		// When
+		err = notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	for testName, testCase := range map[string]assethook.AuthenticationConfig{
+		"MissingKey":   {ClientCertificateFile: "/tmp/cert.pem"},
+		"InvalidPair":  {ClientCertificateFile: "/not/existing/cert.pem", ClientKeyFile: "/not/existing/key.pem"},
+		"MissingToken": {TokenFile: "/not/existing/token"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			_, err := assethook.NewAuthenticator(testCase, logf.Log, nil)
+
+			// Then
+			g.Expect(err).To(gomega.HaveOccurred())
+		})
+	}
+}
+
+// fixClientCertificate writes a self-signed client certificate and returns its files with a pool trusting it
+func fixClientCertificate(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rafter-controller-manager"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	return certFile, keyFile, pool
+}
@@ -18,7 +18,7 @@ type MetadataExtractor struct {
 }
 
 // Extract provides a mock function with given fields: ctx, basePath, files, services
-func (_m *MetadataExtractor) Extract(ctx context.Context, basePath string, files []string, services []v1beta1.WebhookService) ([]assethook.File, error) {
+func (_m *MetadataExtractor) Extract(ctx context.Context, basePath string, files []string, services []v1beta1.WebhookService) ([]assethook.File, []string, error) {
 	ret := _m.Called(ctx, basePath, files, services)
 
 	var r0 []assethook.File
@@ -30,12 +30,21 @@ func (_m *MetadataExtractor) Extract(ctx context.Context, basePath string, files
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, []string, []v1beta1.WebhookService) error); ok {
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, []v1beta1.WebhookService) []string); ok {
 		r1 = rf(ctx, basePath, files, services)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, []string, []v1beta1.WebhookService) error); ok {
+		r2 = rf(ctx, basePath, files, services)
+	} else {
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
@@ -8,4 +8,7 @@ type Config struct {
 	ValidationWorkersCount    int           `envconfig:"default=10"`
 	ValidationTimeout         time.Duration `envconfig:"default=1m"`
 	MetadataExtractionTimeout time.Duration `envconfig:"default=1m"`
+	NotificationTimeout       time.Duration `envconfig:"default=1m"`
+	Retry                     RetryConfig
+	Authentication            AuthenticationConfig
 }
@@ -0,0 +1,184 @@
+package assethook
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+// endpointResolver resolves webhook addresses for all engines and provides HTTP clients trusting the webhook CA bundles and presenting the manager identity
+type endpointResolver struct {
+	httpClient    HttpClient
+	retry         RetryConfig
+	authenticator *Authenticator
+	mux           sync.Mutex
+	clients       map[string]HttpClient
+}
+
+func newEndpointResolver(httpClient HttpClient, retry RetryConfig, authenticator *Authenticator) *endpointResolver {
+	return &endpointResolver{
+		httpClient:    httpClient,
+		retry:         retry,
+		authenticator: authenticator,
+		clients:       make(map[string]HttpClient),
+	}
+}
+
+func (r *endpointResolver) URL(service v1beta1.WebhookService) (string, error) {
+	if service.URL != "" {
+		parsed, err := url.Parse(service.URL)
+		if err != nil {
+			return "", errors.Wrapf(err, "while parsing URL %s", service.URL)
+		}
+		if parsed.Scheme != string(v1beta1.WebhookSchemeHTTP) && parsed.Scheme != string(v1beta1.WebhookSchemeHTTPS) {
+			return "", errors.Errorf("unsupported scheme of URL %s", service.URL)
+		}
+
+		return service.URL, nil
+	}
+
+	if service.Name == "" || service.Namespace == "" {
+		return "", errors.New("either URL or Service name and namespace must be set")
+	}
+
+	scheme := service.Scheme
+	if scheme == "" {
+		scheme = v1beta1.WebhookSchemeHTTP
+	}
+	host := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	if service.Port != nil {
+		host = fmt.Sprintf("%s:%d", host, *service.Port)
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, service.Endpoint), nil
+}
+
+func (r *endpointResolver) Client(service v1beta1.WebhookService) (HttpClient, error) {
+	useCertificate, err := r.authenticator.usesClientCertificate(service)
+	if err != nil {
+		return nil, err
+	}
+	if len(service.CABundle) == 0 && !useCertificate {
+		return r.httpClient, nil
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	key := fmt.Sprintf("%t/%s", useCertificate, service.CABundle)
+	if client, ok := r.clients[key]; ok {
+		return client, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if len(service.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(service.CABundle) {
+			return nil, errors.New("CA bundle doesn't contain any valid certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if useCertificate {
+		tlsConfig.GetClientCertificate = r.authenticator.getClientCertificate
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := &http.Client{Transport: transport}
+	r.clients[key] = client
+
+	return client, nil
+}
+
+// Call sends the body to the webhook, retrying connection errors and 5xx responses with backoff. The body is shared
+// by all attempts without copying. The timeout applies to every attempt and the caller must close the body of the returned response.
+func (r *endpointResolver) Call(ctx context.Context, service v1beta1.WebhookService, timeout time.Duration, contentType string, body []byte) (*http.Response, error) {
+	webhookUrl, err := r.URL(service)
+	if err != nil {
+		return nil, errors.Wrap(err, "while resolving webhook URL")
+	}
+	httpClient, err := r.Client(service)
+	if err != nil {
+		return nil, errors.Wrap(err, "while creating webhook client")
+	}
+	maxRetries := r.retry.maxRetries(service)
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, webhookUrl, bytes.NewReader(body))
+		if err != nil {
+			return nil, errors.Wrap(err, "while creating request")
+		}
+		req.Header.Set("Content-Type", contentType)
+		if err := r.authenticator.authorize(ctx, req, service); err != nil {
+			return nil, errors.Wrap(err, "while authorizing request")
+		}
+
+		rsp, err := r.send(ctx, httpClient, req, webhookTimeout(service, timeout))
+		if attempt >= maxRetries || !isRetryable(rsp, err) {
+			return rsp, err
+		}
+		if rsp != nil {
+			rsp.Body.Close()
+		}
+		if err := r.retry.wait(ctx, attempt); err != nil {
+			return nil, errors.Wrap(err, "while waiting for retry")
+		}
+	}
+}
+
+func (r *endpointResolver) send(ctx context.Context, httpClient HttpClient, req *http.Request, timeout time.Duration) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	req = req.WithContext(ctx)
+
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "while sending request to webhook")
+	}
+	// the body is read after the call returns, so the context is canceled once it is closed
+	rsp.Body = &cancelOnClose{ReadCloser: rsp.Body, cancel: cancel}
+
+	return rsp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// webhookTimeout returns the timeout of the webhook, falling back to the given default
+func webhookTimeout(service v1beta1.WebhookService, defaultTimeout time.Duration) time.Duration {
+	if service.Timeout == nil || service.Timeout.Duration <= 0 {
+		return defaultTimeout
+	}
+
+	return service.Timeout.Duration
+}
+
+// isIgnoringFailures returns true if failed calls to the webhook should not fail the Asset
+func isIgnoringFailures(service v1beta1.WebhookService) bool {
+	return service.FailurePolicy == v1beta1.WebhookFailurePolicyIgnore
+}
+
+// webhookName identifies the webhook in messages
+func webhookName(service v1beta1.WebhookService) string {
+	if service.URL != "" {
+		return service.URL
+	}
+
+	return fmt.Sprintf("%s/%s%s", service.Namespace, service.Name, service.Endpoint)
+}
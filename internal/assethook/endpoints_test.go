@@ -0,0 +1,108 @@
+package assethook_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestResolveWebhookURL(t *testing.T) {
+	port := int32(8443)
+
+	for testName, testCase := range map[string]struct {
+		service  v1beta1.WebhookService
+		expected string
+		err      bool
+	}{
+		"Service": {
+			service:  v1beta1.WebhookService{Name: "test", Namespace: "default", Endpoint: "/validate"},
+			expected: "http://test.default.svc/validate",
+		},
+		"ServiceWithPortAndScheme": {
+			service:  v1beta1.WebhookService{Name: "test", Namespace: "default", Port: &port, Scheme: v1beta1.WebhookSchemeHTTPS, Endpoint: "/validate"},
+			expected: "https://test.default.svc:8443/validate",
+		},
+		"URL": {
+			service:  v1beta1.WebhookService{Name: "ignored", Namespace: "default", URL: "https://webhooks.example.com/validate", Endpoint: "/ignored"},
+			expected: "https://webhooks.example.com/validate",
+		},
+		"UnsupportedScheme": {
+			service: v1beta1.WebhookService{URL: "ftp://webhooks.example.com/validate"},
+			err:     true,
+		},
+		"MissingService": {
+			service: v1beta1.WebhookService{Endpoint: "/validate"},
+			err:     true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			result, err := assethook.ResolveWebhookURL(testCase.service)
+
+			// Then
+			if testCase.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(result).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+func TestEndpointResolver_CABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("Trusted", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		service := v1beta1.WebhookService{URL: server.URL, CABundle: caBundle}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, assethook.RetryConfig{}, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	})
+
+	t.Run("Untrusted", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		service := v1beta1.WebhookService{URL: server.URL}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, assethook.RetryConfig{}, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("InvalidBundle", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		service := v1beta1.WebhookService{URL: server.URL, CABundle: []byte("invalid")}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, assethook.RetryConfig{}, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io"
 	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 )
 
 type Callback func(ctx context.Context, basePath, filePath string, responseBody io.Reader, messagesChan chan Message, errChan chan error)
@@ -11,7 +13,7 @@ type Callback func(ctx context.Context, basePath, filePath string, responseBody
 func NewProcessor(workers int, client HttpClient, continueOnFail bool, onSuccess, onFail Callback) *processor {
 	return &processor{
 		workers:        workers,
-		httpClient:     client,
+		endpoints:      newEndpointResolver(client, RetryConfig{}, nil),
 		onSuccess:      onSuccess,
 		onFail:         onFail,
 		continueOnFail: continueOnFail,
@@ -30,3 +32,7 @@ func NewTestMutator(processor httpProcessor) Mutator {
 		processor: processor,
 	}
 }
+
+func ResolveWebhookURL(service v1beta1.WebhookService) (string, error) {
+	return newEndpointResolver(nil, RetryConfig{}, nil).URL(service)
+}
@@ -21,7 +21,7 @@ import (
 
 //go:generate mockery -name=MetadataExtractor -output=automock -outpkg=automock -case=underscore
 type MetadataExtractor interface {
-	Extract(ctx context.Context, basePath string, files []string, services []v1beta1.WebhookService) ([]File, error)
+	Extract(ctx context.Context, basePath string, files []string, services []v1beta1.WebhookService) ([]File, []string, error)
 }
 
 type File struct {
@@ -32,40 +32,46 @@ type File struct {
 type metadataEngine struct {
 	timeout    time.Duration
 	fileReader func(filename string) ([]byte, error)
-	httpClient HttpClient
+	endpoints  *endpointResolver
 }
 
-func NewMetadataExtractor(httpClient HttpClient, timeout time.Duration) MetadataExtractor {
+func NewMetadataExtractor(httpClient HttpClient, timeout time.Duration, retry RetryConfig, authenticator *Authenticator) MetadataExtractor {
 	return &metadataEngine{
-		httpClient: httpClient,
+		endpoints:  newEndpointResolver(httpClient, retry, authenticator),
 		timeout:    timeout,
 		fileReader: ioutil.ReadFile,
 	}
 }
 
-func (e *metadataEngine) Extract(ctx context.Context, basePath string, files []string, services []v1beta1.WebhookService) ([]File, error) {
+// Extract returns metadata of the files and the failures of webhooks ignored due to their failure policy
+func (e *metadataEngine) Extract(ctx context.Context, basePath string, files []string, services []v1beta1.WebhookService) ([]File, []string, error) {
 	results := make(map[string]*json.RawMessage)
+	var warnings []string
 	for _, service := range services {
 		filtered, err := pkgPath.Filter(files, service.Filter)
 		if err != nil {
-			return nil, errors.Wrapf(err, "while filtering files with regex %s", service.Filter)
+			return nil, nil, errors.Wrapf(err, "while filtering files with regex %s", service.Filter)
 		}
 
 		body, contentType, err := e.buildQuery(basePath, filtered)
 		if err != nil {
-			return nil, errors.Wrap(err, "while building multipart query")
+			return nil, nil, errors.Wrap(err, "while building multipart query")
 		}
 
 		response := &v1alpha1.MetadataResponse{}
 		err = e.do(ctx, contentType, service, body, response)
+		if err != nil && isIgnoringFailures(service) && ctx.Err() == nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", webhookName(service), err.Error()))
+			continue
+		}
 		if err != nil {
-			return nil, errors.Wrap(err, "while sending request to metadata webhook")
+			return nil, nil, errors.Wrap(err, "while sending request to metadata webhook")
 		}
 
 		results = e.replaceMetadata(results, response.Data)
 	}
 
-	return e.toFiles(results), nil
+	return e.toFiles(results), warnings, nil
 }
 
 func (*metadataEngine) replaceMetadata(current map[string]*json.RawMessage, results []v1alpha1.MetadataResultSuccess) map[string]*json.RawMessage {
@@ -85,10 +91,9 @@ func (*metadataEngine) toFiles(results map[string]*json.RawMessage) []File {
 	return files
 }
 
-func (e *metadataEngine) buildQuery(basePath string, files []string) (io.Reader, string, error) {
+func (e *metadataEngine) buildQuery(basePath string, files []string) ([]byte, string, error) {
 	b := &bytes.Buffer{}
 	formWriter := multipart.NewWriter(b)
-	defer formWriter.Close()
 
 	for _, file := range files {
 		path := filepath.Join(basePath, file)
@@ -96,8 +101,11 @@ func (e *metadataEngine) buildQuery(basePath string, files []string) (io.Reader,
 			return nil, "", errors.Wrapf(err, "while building query part")
 		}
 	}
+	if err := formWriter.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "while closing multipart form")
+	}
 
-	return b, formWriter.FormDataContentType(), nil
+	return b.Bytes(), formWriter.FormDataContentType(), nil
 }
 
 func (e *metadataEngine) buildQueryField(writer *multipart.Writer, filename, path string) error {
@@ -120,26 +128,15 @@ func (e *metadataEngine) buildQueryField(writer *multipart.Writer, filename, pat
 	return nil
 }
 
-func (e *metadataEngine) do(ctx context.Context, contentType string, webhook v1beta1.WebhookService, body io.Reader, response interface{}) error {
-	ctx, cancel := context.WithTimeout(ctx, e.timeout)
-	defer cancel()
-
-	req, err := http.NewRequest("POST", e.getWebhookUrl(webhook), body)
+func (e *metadataEngine) do(ctx context.Context, contentType string, webhook v1beta1.WebhookService, body []byte, response interface{}) error {
+	rsp, err := e.endpoints.Call(ctx, webhook, e.timeout, contentType, body)
 	if err != nil {
 		return err
 	}
-
-	req.Header.Set("Content-Type", contentType)
-	req.WithContext(ctx)
-
-	rsp, err := e.httpClient.Do(req)
-	if err != nil {
-		return errors.Wrapf(err, "while sending request to webhook")
-	}
 	defer rsp.Body.Close()
 
 	if rsp.StatusCode != http.StatusOK {
-		return fmt.Errorf("invalid response from %s, code: %d", req.URL, rsp.StatusCode)
+		return fmt.Errorf("invalid response from %s, code: %d", webhookName(webhook), rsp.StatusCode)
 	}
 
 	responseBytes, err := ioutil.ReadAll(rsp.Body)
@@ -154,7 +151,3 @@ func (e *metadataEngine) do(ctx context.Context, contentType string, webhook v1b
 
 	return nil
 }
-
-func (*metadataEngine) getWebhookUrl(service v1beta1.WebhookService) string {
-	return fmt.Sprintf("http://%s.%s.svc.cluster.local%s", service.Name, service.Namespace, service.Endpoint)
-}
@@ -22,7 +22,7 @@ type Mutator interface {
 	Mutate(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (Result, error)
 }
 
-func NewMutator(httpClient HttpClient, timeout time.Duration, workers int) Mutator {
+func NewMutator(httpClient HttpClient, timeout time.Duration, workers int, retry RetryConfig, authenticator *Authenticator) Mutator {
 	return &mutationEngine{
 		processor: &processor{
 			timeout:        timeout,
@@ -30,7 +30,7 @@ func NewMutator(httpClient HttpClient, timeout time.Duration, workers int) Mutat
 			onFail:         mutationFailureHandler,
 			onSuccess:      mutationSuccessHandler,
 			continueOnFail: false,
-			httpClient:     httpClient,
+			endpoints:      newEndpointResolver(httpClient, retry, authenticator),
 		},
 	}
 }
@@ -58,7 +58,7 @@ func mutationFailureHandler(_ context.Context, _, filePath string, responseBody
 }
 
 func (e *mutationEngine) Mutate(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (Result, error) {
-	results, err := e.processor.Do(ctx, basePath, files, services)
+	results, warnings, err := e.processor.Do(ctx, basePath, files, services)
 	if err != nil {
 		return Result{}, errors.Wrap(err, "while mutating")
 	}
@@ -66,5 +66,6 @@ func (e *mutationEngine) Mutate(ctx context.Context, basePath string, files []st
 	return Result{
 		Success:  len(results) == 0,
 		Messages: results,
+		Warnings: warnings,
 	}, nil
 }
@@ -38,7 +38,7 @@ func TestMutationEngine_Mutate(t *testing.T) {
 			files := []string{}
 			services := []v1beta1.AssetWebhookService{}
 
-			processor.On("Do", ctx, "", files, services).Return(testCase.messages, testCase.err).Once()
+			processor.On("Do", ctx, "", files, services).Return(testCase.messages, nil, testCase.err).Once()
 			mutator := assethook.NewTestMutator(processor)
 
 			// When
@@ -0,0 +1,59 @@
+package assethook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/assethook/api/v1alpha1"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockery -name=Notifier -output=automock -outpkg=automock -case=underscore
+type Notifier interface {
+	Notify(ctx context.Context, notification v1alpha1.NotificationRequest, services []v1beta1.WebhookService) error
+}
+
+type notificationEngine struct {
+	timeout   time.Duration
+	endpoints *endpointResolver
+}
+
+func NewNotifier(httpClient HttpClient, timeout time.Duration, retry RetryConfig, authenticator *Authenticator) Notifier {
+	return &notificationEngine{
+		endpoints: newEndpointResolver(httpClient, retry, authenticator),
+		timeout:   timeout,
+	}
+}
+
+func (e *notificationEngine) Notify(ctx context.Context, notification v1alpha1.NotificationRequest, services []v1beta1.WebhookService) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "while marshalling notification")
+	}
+
+	for _, service := range services {
+		if err := e.do(ctx, service, body); err != nil {
+			return errors.Wrapf(err, "while sending notification to %s", webhookName(service))
+		}
+	}
+
+	return nil
+}
+
+func (e *notificationEngine) do(ctx context.Context, webhook v1beta1.WebhookService, body []byte) error {
+	rsp, err := e.endpoints.Call(ctx, webhook, e.timeout, "application/json", body)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < http.StatusOK || rsp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("invalid response from %s, code: %d", webhookName(webhook), rsp.StatusCode)
+	}
+
+	return nil
+}
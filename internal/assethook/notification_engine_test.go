@@ -0,0 +1,85 @@
+package assethook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/assethook/api/v1alpha1"
+	"github.com/kyma-project/rafter/internal/assethook/automock"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationEngine_Notify(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		notification := fixNotification()
+		services := []v1beta1.WebhookService{
+			fixService("first", "test", "/notify").WebhookService,
+			fixService("second", "test", "/notify").WebhookService,
+		}
+		client := new(automock.HttpClient)
+		defer client.AssertExpectations(t)
+		client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return false
+			}
+			received := v1alpha1.NotificationRequest{}
+			if err := json.Unmarshal(body, &received); err != nil {
+				return false
+			}
+			return req.Header.Get("Content-Type") == "application/json" && received.Name == notification.Name && received.BaseURL == notification.BaseURL
+		})).Return(fixHttpResponse(http.StatusNoContent, ""), nil).Twice()
+
+		notifier := assethook.NewNotifier(client, time.Minute, assethook.RetryConfig{}, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), notification, services)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	})
+
+	t.Run("InvalidResponse", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		services := []v1beta1.WebhookService{
+			fixService("first", "test", "/notify").WebhookService,
+			fixService("second", "test", "/notify").WebhookService,
+		}
+		client := new(automock.HttpClient)
+		defer client.AssertExpectations(t)
+		client.On("Do", mock.Anything).Return(fixHttpResponse(http.StatusInternalServerError, ""), nil).Once()
+
+		notifier := assethook.NewNotifier(client, time.Minute, assethook.RetryConfig{}, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), services)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func fixNotification() v1alpha1.NotificationRequest {
+	return v1alpha1.NotificationRequest{
+		Event:      v1alpha1.NotificationUploaded,
+		Namespace:  "test",
+		Name:       "asset",
+		Generation: 2,
+		BaseURL:    "https://minio.local/bucket/asset",
+		Files: []v1alpha1.NotificationFile{
+			{Name: "README.md", URL: "https://minio.local/bucket/asset/README.md"},
+		},
+	}
+}
@@ -3,24 +3,31 @@ package assethook
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/kyma-project/rafter/internal/assethook/api/v1alpha1"
 	pkgPath "github.com/kyma-project/rafter/internal/path"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const defaultMaxBatchSize = 100
+
 type Result struct {
 	Success  bool
 	Messages map[string][]Message
+	// Warnings are the failures of webhooks ignored due to their failure policy
+	Warnings []string
 }
 
 type Message struct {
@@ -34,7 +41,7 @@ type processor struct {
 	workers        int
 	continueOnFail bool
 	timeout        time.Duration
-	httpClient     HttpClient
+	endpoints      *endpointResolver
 }
 
 //go:generate mockery -name=HttpClient -output=automock -outpkg=automock -case=underscore
@@ -44,7 +51,7 @@ type HttpClient interface {
 
 //go:generate mockery -name=httpProcessor -output=automock -outpkg=automock -case=underscore
 type httpProcessor interface {
-	Do(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (map[string][]Message, error)
+	Do(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (map[string][]Message, []string, error)
 }
 
 func (*processor) parseParameters(metadata *runtime.RawExtension) string {
@@ -55,41 +62,65 @@ func (*processor) parseParameters(metadata *runtime.RawExtension) string {
 	return string(metadata.Raw)
 }
 
-func (p *processor) iterateFiles(files []string, filter string) (chan string, error) {
+// iterateFiles splits the filtered files into batches of the given size
+func (p *processor) iterateFiles(files []string, filter string, batchSize int) (chan []string, error) {
 	filtered, err := pkgPath.Filter(files, filter)
 	if err != nil {
 		return nil, errors.Wrapf(err, "while filtering files with regex %s", filter)
 	}
 
-	fileNameChan := make(chan string, len(filtered))
-	defer close(fileNameChan)
-	for _, fileName := range filtered {
-		fileNameChan <- fileName
+	batchChan := make(chan []string, (len(filtered)+batchSize-1)/batchSize)
+	defer close(batchChan)
+	for start := 0; start < len(filtered); start += batchSize {
+		end := start + batchSize
+		if end > len(filtered) {
+			end = len(filtered)
+		}
+		batchChan <- filtered[start:end]
 	}
 
-	return fileNameChan, nil
+	return batchChan, nil
 }
 
-func (p *processor) Do(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (map[string][]Message, error) {
-	ctx, cancel := context.WithCancel(ctx)
+func (p *processor) batchSize(service v1beta1.AssetWebhookService) int {
+	if !service.Batch {
+		return 1
+	}
+	if service.MaxBatchSize != nil && *service.MaxBatchSize > 0 {
+		return int(*service.MaxBatchSize)
+	}
+
+	return defaultMaxBatchSize
+}
+
+// Do calls the webhooks for the files and returns their messages and the failures of webhooks ignored due to their failure policy
+func (p *processor) Do(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (map[string][]Message, []string, error) {
+	processCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	results := make(map[string][]Message)
+	var warnings []string
 	for _, service := range services {
-		success, messages, err := p.doService(ctx, cancel, basePath, files, service)
+		success, messages, err := p.doService(processCtx, cancel, basePath, files, service)
+		if ctx.Err() != nil {
+			return nil, nil, errors.Wrapf(ctx.Err(), "while calling webhook %s", webhookName(service.WebhookService))
+		}
+		if err != nil && isIgnoringFailures(service.WebhookService) {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", webhookName(service.WebhookService), err.Error()))
+			continue
+		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if !success {
-			name := fmt.Sprintf("%s/%s%s", service.Namespace, service.Name, service.Endpoint)
-			results[name] = messages
+			results[webhookName(service.WebhookService)] = messages
 		}
 	}
 
-	return results, nil
+	return results, warnings, nil
 }
 
 func (p *processor) doService(ctx context.Context, cancel context.CancelFunc, basePath string, files []string, service v1beta1.AssetWebhookService) (bool, []Message, error) {
-	fileChan, err := p.iterateFiles(files, service.Filter)
+	fileChan, err := p.iterateFiles(files, service.Filter, p.batchSize(service))
 	if err != nil {
 		return false, nil, errors.Wrap(err, "while creating files channel")
 	}
@@ -144,19 +175,23 @@ func (p *processor) doService(ctx context.Context, cancel context.CancelFunc, ba
 	return false, messages, nil
 }
 
-func (p *processor) doFiles(ctx context.Context, cancel context.CancelFunc, basePath string, service v1beta1.AssetWebhookService, pathChan chan string, messagesChan chan Message, errChan chan error) {
+func (p *processor) doFiles(ctx context.Context, cancel context.CancelFunc, basePath string, service v1beta1.AssetWebhookService, batchChan chan []string, messagesChan chan Message, errChan chan error) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-errChan:
 			return
-		case path, ok := <-pathChan:
+		case paths, ok := <-batchChan:
 			if !ok {
 				return
 			}
 
-			p.doFile(ctx, cancel, basePath, path, service, messagesChan, errChan)
+			if service.Batch {
+				p.doBatch(ctx, cancel, basePath, paths, service, messagesChan, errChan)
+			} else {
+				p.doFile(ctx, cancel, basePath, paths[0], service, messagesChan, errChan)
+			}
 		}
 	}
 }
@@ -186,10 +221,85 @@ func (p *processor) doFile(ctx context.Context, cancel context.CancelFunc, baseP
 	}
 }
 
-func (p *processor) buildQuery(basePath, filePath, parameters string) (io.Reader, string, error) {
+func (p *processor) doBatch(ctx context.Context, cancel context.CancelFunc, basePath string, paths []string, service v1beta1.AssetWebhookService, messagesChan chan Message, errChan chan error) {
+	body, contentType, err := p.buildBatchQuery(basePath, paths, p.parseParameters(service.Parameters))
+	if err != nil {
+		errChan <- errors.Wrap(err, "while building multipart batch query")
+		return
+	}
+
+	results, err := p.callBatch(ctx, contentType, service.WebhookService, body)
+	if err != nil {
+		errChan <- errors.Wrap(err, "while sending batch request to webhook")
+		return
+	}
+
+	success := true
+	for _, path := range paths {
+		result, ok := results[path]
+		if !ok {
+			errChan <- errors.Errorf("missing result for file %s in batch response", path)
+			return
+		}
+
+		if result.Success && result.Modified && p.onSuccess != nil {
+			p.onSuccess(ctx, basePath, path, bytes.NewReader(result.Content), messagesChan, errChan)
+		} else if !result.Success && p.onFail != nil {
+			p.onFail(ctx, basePath, path, strings.NewReader(result.Message), messagesChan, errChan)
+		}
+		success = success && result.Success
+	}
+
+	if !success && !p.continueOnFail {
+		cancel()
+	}
+}
+
+func (p *processor) buildBatchQuery(basePath string, filePaths []string, parameters string) ([]byte, string, error) {
+	buffer := &bytes.Buffer{}
+	formWriter := multipart.NewWriter(buffer)
+
+	for _, filePath := range filePaths {
+		if err := p.writeBatchFile(formWriter, basePath, filePath); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := formWriter.WriteField(v1alpha1.BatchField, "true"); err != nil {
+		return nil, "", errors.Wrap(err, "while creating batch field")
+	}
+	if err := formWriter.WriteField("parameters", parameters); err != nil {
+		return nil, "", errors.Wrapf(err, "while creating parameters field for parameters %s", parameters)
+	}
+	if err := formWriter.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "while closing multipart form")
+	}
+
+	return buffer.Bytes(), formWriter.FormDataContentType(), nil
+}
+
+func (p *processor) writeBatchFile(formWriter *multipart.Writer, basePath, filePath string) error {
+	file, err := os.Open(filepath.Join(basePath, filePath))
+	if err != nil {
+		return errors.Wrapf(err, "while opening file %s", filePath)
+	}
+	defer file.Close()
+
+	contentWriter, err := formWriter.CreateFormFile(filePath, filepath.Base(file.Name()))
+	if err != nil {
+		return errors.Wrapf(err, "while creating field for file %s", filePath)
+	}
+
+	if _, err := io.Copy(contentWriter, file); err != nil {
+		return errors.Wrapf(err, "while copying file %s to its field", filePath)
+	}
+
+	return nil
+}
+
+func (p *processor) buildQuery(basePath, filePath, parameters string) ([]byte, string, error) {
 	buffer := &bytes.Buffer{}
 	formWriter := multipart.NewWriter(buffer)
-	defer formWriter.Close()
 
 	path := filepath.Join(basePath, filePath)
 	file, err := os.Open(path)
@@ -212,25 +322,17 @@ func (p *processor) buildQuery(basePath, filePath, parameters string) (io.Reader
 	if err != nil {
 		return nil, "", errors.Wrapf(err, "while creating parameters field for parameters %s", parameters)
 	}
-
-	return buffer, formWriter.FormDataContentType(), nil
-}
-
-func (p *processor) call(ctx context.Context, contentType string, webhook v1beta1.WebhookService, body io.Reader) (bool, bool, io.ReadCloser, error) {
-	context, cancel := context.WithTimeout(ctx, p.timeout)
-	defer cancel()
-
-	req, err := http.NewRequest("POST", p.getWebhookUrl(webhook), body)
-	if err != nil {
-		return false, false, nil, errors.Wrap(err, "while creating request")
+	if err := formWriter.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "while closing multipart form")
 	}
 
-	req.Header.Set("Content-Type", contentType)
-	req.WithContext(context)
+	return buffer.Bytes(), formWriter.FormDataContentType(), nil
+}
 
-	rsp, err := p.httpClient.Do(req)
+func (p *processor) call(ctx context.Context, contentType string, webhook v1beta1.WebhookService, body []byte) (bool, bool, io.ReadCloser, error) {
+	rsp, err := p.endpoints.Call(ctx, webhook, p.timeout, contentType, body)
 	if err != nil {
-		return false, false, nil, errors.Wrapf(err, "while sending request to webhook")
+		return false, false, nil, err
 	}
 
 	switch rsp.StatusCode {
@@ -240,10 +342,31 @@ func (p *processor) call(ctx context.Context, contentType string, webhook v1beta
 	case http.StatusNotModified:
 		return true, false, rsp.Body, nil
 	default:
-		return false, false, rsp.Body, fmt.Errorf("invalid response from %s, code: %d", req.URL, rsp.StatusCode)
+		rsp.Body.Close()
+		return false, false, nil, fmt.Errorf("invalid response from %s, code: %d", webhookName(webhook), rsp.StatusCode)
 	}
 }
 
-func (*processor) getWebhookUrl(service v1beta1.WebhookService) string {
-	return fmt.Sprintf("http://%s.%s.svc.cluster.local%s", service.Name, service.Namespace, service.Endpoint)
+func (p *processor) callBatch(ctx context.Context, contentType string, webhook v1beta1.WebhookService, body []byte) (map[string]v1alpha1.BatchResult, error) {
+	rsp, err := p.endpoints.Call(ctx, webhook, p.timeout, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid response from %s, code: %d", webhookName(webhook), rsp.StatusCode)
+	}
+
+	response := v1alpha1.BatchResponse{}
+	if err := json.NewDecoder(rsp.Body).Decode(&response); err != nil {
+		return nil, errors.Wrap(err, "while parsing batch response")
+	}
+
+	results := make(map[string]v1alpha1.BatchResult, len(response.Results))
+	for _, result := range response.Results {
+		results[result.FilePath] = result
+	}
+
+	return results, nil
 }
@@ -1,19 +1,26 @@
 package assethook_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/rafter/internal/assethook"
 	"github.com/kyma-project/rafter/internal/assethook/automock"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/kyma-project/rafter/pkg/runtime/endpoint"
 	"github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestProcessor_Do(t *testing.T) {
@@ -29,7 +36,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback(nil, nil))
 
 		// When
-		result, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+		result, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).ToNot(gomega.HaveOccurred())
@@ -48,7 +55,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback(nil, nil))
 
 		// When
-		_, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+		_, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -66,7 +73,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback([]string{"err"}, nil))
 
 		// When
-		result, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+		result, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).ToNot(gomega.HaveOccurred())
@@ -85,7 +92,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback(nil, []error{fmt.Errorf("test")}))
 
 		// When
-		result, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+		result, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -104,7 +111,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback([]string{"err"}, nil), testCallback(nil, nil))
 
 		// When
-		result, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+		result, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).ToNot(gomega.HaveOccurred())
@@ -123,7 +130,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback(nil, []error{fmt.Errorf("test")}), testCallback(nil, nil))
 
 		// When
-		result, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+		result, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -141,7 +148,7 @@ func TestProcessor_Do(t *testing.T) {
 		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback(nil, nil))
 
 		// When
-		result, err := processor.Do(context.TODO(), "./", []string{"xyz.go"}, []v1beta1.AssetWebhookService{service})
+		result, _, err := processor.Do(context.TODO(), "./", []string{"xyz.go"}, []v1beta1.AssetWebhookService{service})
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -149,6 +156,228 @@ func TestProcessor_Do(t *testing.T) {
 	})
 }
 
+func TestProcessor_Do_FailurePolicy(t *testing.T) {
+	t.Run("Ignore", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		service := fixService("test", "test", "/test")
+		service.FailurePolicy = v1beta1.WebhookFailurePolicyIgnore
+		client := new(automock.HttpClient)
+		defer client.AssertExpectations(t)
+		client.On("Do", mock.Anything).Return(fixHttpResponse(http.StatusInternalServerError, ""), nil).Once()
+
+		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback(nil, nil))
+
+		// When
+		result, warnings, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result).To(gomega.HaveLen(0))
+		g.Expect(warnings).To(gomega.HaveLen(1))
+		g.Expect(warnings[0]).To(gomega.HavePrefix("test/test/test: "))
+	})
+
+	t.Run("Ignore keeps messages", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		service := fixService("test", "test", "/test")
+		service.FailurePolicy = v1beta1.WebhookFailurePolicyIgnore
+		client := new(automock.HttpClient)
+		defer client.AssertExpectations(t)
+		client.On("Do", mock.Anything).Return(fixHttpResponse(http.StatusUnprocessableEntity, ""), nil).Once()
+
+		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback([]string{"invalid"}, nil))
+
+		// When
+		result, warnings, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result).To(gomega.HaveLen(1))
+		g.Expect(warnings).To(gomega.BeEmpty())
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		service := fixService("test", "test", "/test")
+		service.FailurePolicy = v1beta1.WebhookFailurePolicyFail
+		client := new(automock.HttpClient)
+		defer client.AssertExpectations(t)
+		client.On("Do", mock.Anything).Return(fixHttpResponse(http.StatusInternalServerError, ""), nil).Once()
+
+		processor := assethook.NewProcessor(2, client, false, testCallback(nil, nil), testCallback(nil, nil))
+
+		// When
+		_, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestProcessor_Do_Batch(t *testing.T) {
+	t.Run("Validation", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		requests := 0
+		validation := endpoint.NewValidation("validate", &rejectingValidator{reject: "package assethook\n"})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			validation.Handle(w, r)
+		}))
+		defer server.Close()
+
+		maxBatchSize := int32(2)
+		service := v1beta1.AssetWebhookService{
+			WebhookService: v1beta1.WebhookService{URL: server.URL},
+			Batch:          true,
+			MaxBatchSize:   &maxBatchSize,
+		}
+		validator := assethook.NewValidator(http.DefaultClient, time.Minute, 1, assethook.RetryConfig{}, nil)
+
+		// When
+		result, err := validator.Validate(context.TODO(), "./", []string{"processor.go", "processor_test.go", "retry_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.Success).To(gomega.BeFalse())
+		g.Expect(result.Messages).To(gomega.HaveKeyWithValue(server.URL, []assethook.Message{{Filename: "processor.go", Message: "rejected"}}))
+		g.Expect(requests).To(gomega.Equal(2))
+	})
+
+	t.Run("Mutation", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		basePath, err := ioutil.TempDir("", "batch")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		defer os.RemoveAll(basePath)
+		g.Expect(os.Mkdir(filepath.Join(basePath, "docs"), os.ModePerm)).To(gomega.Succeed())
+		for _, name := range []string{"a.md", "docs/b.md"} {
+			g.Expect(ioutil.WriteFile(filepath.Join(basePath, name), []byte(name), os.ModePerm)).To(gomega.Succeed())
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(endpoint.NewMutation("mutate", &upperMutator{}).Handle))
+		defer server.Close()
+
+		service := v1beta1.AssetWebhookService{WebhookService: v1beta1.WebhookService{URL: server.URL}, Batch: true}
+		mutator := assethook.NewMutator(http.DefaultClient, time.Minute, 1, assethook.RetryConfig{}, nil)
+
+		// When
+		result, err := mutator.Mutate(context.TODO(), basePath, []string{"a.md", "docs/b.md"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result.Success).To(gomega.BeTrue())
+		for _, name := range []string{"a.md", "docs/b.md"} {
+			content, err := ioutil.ReadFile(filepath.Join(basePath, name))
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(string(content)).To(gomega.Equal(strings.ToUpper(name)))
+		}
+	})
+
+	t.Run("Missing result", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"results":[]}`))
+		}))
+		defer server.Close()
+
+		service := v1beta1.AssetWebhookService{WebhookService: v1beta1.WebhookService{URL: server.URL}, Batch: true}
+		validator := assethook.NewValidator(http.DefaultClient, time.Minute, 1, assethook.RetryConfig{}, nil)
+
+		// When
+		_, err := validator.Validate(context.TODO(), "./", []string{"processor.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestProcessor_Do_Timeout(t *testing.T) {
+	t.Run("Webhook timeout", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := newHangingServer()
+		defer server.Close()
+
+		service := v1beta1.AssetWebhookService{WebhookService: v1beta1.WebhookService{
+			URL:     server.URL,
+			Timeout: &metav1.Duration{Duration: 50 * time.Millisecond},
+		}}
+		processor := assethook.NewProcessor(1, http.DefaultClient, false, testCallback(nil, nil), testCallback(nil, nil))
+
+		// When
+		_, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := newHangingServer()
+		defer server.Close()
+
+		service := v1beta1.AssetWebhookService{WebhookService: v1beta1.WebhookService{URL: server.URL}}
+		processor := assethook.NewProcessor(1, http.DefaultClient, false, testCallback(nil, nil), testCallback(nil, nil))
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+
+		// When
+		_, _, err := processor.Do(ctx, "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("Response read by callback", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte("invalid"))
+		}))
+		defer server.Close()
+
+		service := v1beta1.AssetWebhookService{WebhookService: v1beta1.WebhookService{URL: server.URL}}
+		onFail := func(ctx context.Context, basePath, filePath string, responseBody io.Reader, messagesChan chan assethook.Message, errChan chan error) {
+			body, err := ioutil.ReadAll(responseBody)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			messagesChan <- assethook.Message{Filename: filePath, Message: string(body)}
+		}
+		processor := assethook.NewProcessor(1, http.DefaultClient, false, testCallback(nil, nil), onFail)
+
+		// When
+		result, _, err := processor.Do(context.TODO(), "./", []string{"processor_test.go"}, []v1beta1.AssetWebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(result).To(gomega.HaveKeyWithValue(server.URL, []assethook.Message{{Filename: "processor_test.go", Message: "invalid"}}))
+	})
+}
+
+// newHangingServer starts a server responding only after the client gives up
+func newHangingServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
 func testCallback(messages []string, errors []error) assethook.Callback {
 	return func(ctx context.Context, basePath, filePath string, responseBody io.Reader, messagesChan chan assethook.Message, errChan chan error) {
 		for _, err := range errors {
@@ -177,3 +406,31 @@ func fixHttpResponse(statusCode int, body string) *http.Response {
 		Body:       ioutil.NopCloser(strings.NewReader(body)),
 	}
 }
+
+// rejectingValidator rejects files containing the given text
+type rejectingValidator struct {
+	reject string
+}
+
+func (v *rejectingValidator) Validate(ctx context.Context, reader io.Reader, parameters string) error {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(string(content), v.reject) {
+		return fmt.Errorf("rejected")
+	}
+
+	return nil
+}
+
+type upperMutator struct{}
+
+func (m *upperMutator) Mutate(ctx context.Context, reader io.Reader, parameters string) ([]byte, bool, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return bytes.ToUpper(content), true, nil
+}
@@ -0,0 +1,59 @@
+package assethook
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+)
+
+// RetryConfig defines how failed webhook calls are retried. The interval between retries doubles up to the maximum interval.
+type RetryConfig struct {
+	MaxRetries      int           `envconfig:"default=2"`
+	InitialInterval time.Duration `envconfig:"default=1s"`
+	MaxInterval     time.Duration `envconfig:"default=10s"`
+}
+
+// maxRetries returns the number of retries of the webhook, falling back to the configured one
+func (c RetryConfig) maxRetries(service v1beta1.WebhookService) int {
+	if service.MaxRetries != nil {
+		return int(*service.MaxRetries)
+	}
+
+	return c.MaxRetries
+}
+
+func (c RetryConfig) interval(attempt int) time.Duration {
+	interval := c.InitialInterval
+	for i := 0; i < attempt; i++ {
+		interval *= 2
+		if c.MaxInterval > 0 && interval >= c.MaxInterval {
+			return c.MaxInterval
+		}
+	}
+
+	return interval
+}
+
+// wait blocks until the next retry after the given attempt or until the context is done
+func (c RetryConfig) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(c.interval(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryable returns true for connection errors and 5xx responses
+func isRetryable(rsp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return rsp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,137 @@
+package assethook_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestRetry(t *testing.T) {
+	retry := assethook.RetryConfig{MaxRetries: 2, InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+	for testName, testCase := range map[string]struct {
+		statusCodes []int
+		maxRetries  *int32
+		requests    int
+		success     bool
+	}{
+		"RetriedServerError": {
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK},
+			requests:    3,
+			success:     true,
+		},
+		"RetriesExhausted": {
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			requests:    3,
+			success:     false,
+		},
+		"ClientErrorNotRetried": {
+			statusCodes: []int{http.StatusBadRequest, http.StatusOK},
+			requests:    1,
+			success:     false,
+		},
+		"WebhookMaxRetries": {
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries:  new(int32),
+			requests:    1,
+			success:     false,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			server := newSequenceServer(testCase.statusCodes...)
+			defer server.Close()
+
+			service := v1beta1.WebhookService{URL: server.URL, MaxRetries: testCase.maxRetries}
+			notifier := assethook.NewNotifier(&http.Client{}, time.Minute, retry, nil)
+
+			// When
+			err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+			// Then
+			if testCase.success {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).To(gomega.HaveOccurred())
+			}
+			g.Expect(server.count()).To(gomega.Equal(testCase.requests))
+		})
+	}
+
+	t.Run("SameBodyOnRetry", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := newSequenceServer(http.StatusServiceUnavailable, http.StatusOK)
+		defer server.Close()
+
+		service := v1beta1.WebhookService{URL: server.URL}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, retry, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(server.bodies).To(gomega.HaveLen(2))
+		g.Expect(server.bodies[0]).ToNot(gomega.BeEmpty())
+		g.Expect(server.bodies[1]).To(gomega.Equal(server.bodies[0]))
+	})
+
+	t.Run("ConnectionError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		server := newSequenceServer(http.StatusOK)
+		server.Close()
+
+		service := v1beta1.WebhookService{URL: server.URL}
+		notifier := assethook.NewNotifier(&http.Client{}, time.Minute, retry, nil)
+
+		// When
+		err := notifier.Notify(context.TODO(), fixNotification(), []v1beta1.WebhookService{service})
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+type sequenceServer struct {
+	*httptest.Server
+	mux      sync.Mutex
+	requests int
+	bodies   []string
+}
+
+// newSequenceServer starts a server responding with the given status codes in order, repeating the last one
+func newSequenceServer(statusCodes ...int) *sequenceServer {
+	server := &sequenceServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mux.Lock()
+		defer server.mux.Unlock()
+
+		index := server.requests
+		if index >= len(statusCodes) {
+			index = len(statusCodes) - 1
+		}
+		server.requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		server.bodies = append(server.bodies, string(body))
+		w.WriteHeader(statusCodes[index])
+	}))
+
+	return server
+}
+
+func (s *sequenceServer) count() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.requests
+}
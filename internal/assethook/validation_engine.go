@@ -19,14 +19,14 @@ type Validator interface {
 	Validate(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (Result, error)
 }
 
-func NewValidator(httpClient HttpClient, timeout time.Duration, workers int) *validationEngine {
+func NewValidator(httpClient HttpClient, timeout time.Duration, workers int, retry RetryConfig, authenticator *Authenticator) *validationEngine {
 	return &validationEngine{
 		processor: &processor{
 			timeout:        timeout,
 			workers:        workers,
 			onFail:         validationFailureHandler,
 			continueOnFail: true,
-			httpClient:     httpClient,
+			endpoints:      newEndpointResolver(httpClient, retry, authenticator),
 		},
 	}
 }
@@ -40,7 +40,7 @@ func validationFailureHandler(_ context.Context, _, filePath string, responseBod
 }
 
 func (e *validationEngine) Validate(ctx context.Context, basePath string, files []string, services []v1beta1.AssetWebhookService) (Result, error) {
-	results, err := e.processor.Do(ctx, basePath, files, services)
+	results, warnings, err := e.processor.Do(ctx, basePath, files, services)
 	if err != nil {
 		return Result{}, errors.Wrap(err, "while validating")
 	}
@@ -48,5 +48,6 @@ func (e *validationEngine) Validate(ctx context.Context, basePath string, files
 	return Result{
 		Success:  len(results) == 0,
 		Messages: results,
+		Warnings: warnings,
 	}, nil
 }
@@ -38,7 +38,7 @@ func TestValidationEngine_Validate(t *testing.T) {
 			files := []string{}
 			services := []v1beta1.AssetWebhookService{}
 
-			processor.On("Do", ctx, "", files, services).Return(testCase.messages, testCase.err).Once()
+			processor.On("Do", ctx, "", files, services).Return(testCase.messages, nil, testCase.err).Once()
 			validator := assethook.NewTestValidator(processor)
 
 			// When
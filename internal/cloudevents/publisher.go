@@ -0,0 +1,251 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	SpecVersion = "1.0"
+	TypePrefix  = "io.kyma.rafter"
+
+	ModeBinary     = "binary"
+	ModeStructured = "structured"
+
+	structuredContentType = "application/cloudevents+json"
+	dataContentType       = "application/json"
+)
+
+type Config struct {
+	Enabled       bool          `envconfig:"default=false"`
+	SinkURL       string        `envconfig:"optional"`
+	Mode          string        `envconfig:"default=binary"`
+	Source        string        `envconfig:"default=rafter-controller-manager"`
+	Timeout       time.Duration `envconfig:"default=10s"`
+	MaxRetries    int           `envconfig:"default=3"`
+	RetryInterval time.Duration `envconfig:"default=1s"`
+	QueueSize     int           `envconfig:"default=1000"`
+}
+
+// Event is a single CloudEvent emitted by the controllers
+type Event struct {
+	ID      string
+	Type    string
+	Subject string
+	Time    time.Time
+	Data    interface{}
+}
+
+// Emitter queues events for delivery
+type Emitter interface {
+	Emit(event Event)
+}
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type structuredEvent struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            string      `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data,omitempty"`
+}
+
+// Publisher delivers queued events to the configured sink, retrying failed deliveries and writing undelivered events to the dead-letter log
+type Publisher struct {
+	cfg        Config
+	log        logr.Logger
+	deadLetter logr.Logger
+	httpClient HttpClient
+	queue      chan Event
+	sleep      func(ctx context.Context, d time.Duration)
+}
+
+var _ Emitter = &Publisher{}
+var _ manager.Runnable = &Publisher{}
+
+func New(cfg Config, log logr.Logger, httpClient HttpClient) (*Publisher, error) {
+	if cfg.SinkURL == "" {
+		return nil, errors.New("sink URL is required")
+	}
+	if cfg.Mode != ModeBinary && cfg.Mode != ModeStructured {
+		return nil, errors.Errorf("invalid mode %s, expected %s or %s", cfg.Mode, ModeBinary, ModeStructured)
+	}
+
+	return &Publisher{
+		cfg:        cfg,
+		log:        log,
+		deadLetter: log.WithName("dead-letter"),
+		httpClient: httpClient,
+		queue:      make(chan Event, cfg.QueueSize),
+		sleep:      sleep,
+	}, nil
+}
+
+// Type builds the event type for the given kind and action, e.g. io.kyma.rafter.asset.ready
+func Type(kind, action string) string {
+	return fmt.Sprintf("%s.%s.%s", TypePrefix, strings.ToLower(kind), strings.ToLower(action))
+}
+
+// Emit queues the event without blocking. The event goes to the dead-letter log when the queue is full.
+func (p *Publisher) Emit(event Event) {
+	select {
+	case p.queue <- event:
+	default:
+		p.writeDeadLetter(event, errors.New("event queue is full"))
+	}
+}
+
+// Start delivers queued events until the stop channel is closed
+func (p *Publisher) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-p.queue:
+			p.Deliver(ctx, event)
+		}
+	}
+}
+
+// Deliver sends the event to the sink, retrying failed attempts
+func (p *Publisher) Deliver(ctx context.Context, event Event) {
+	var err error
+	for attempt := 0; attempt <= p.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			p.sleep(ctx, p.cfg.RetryInterval)
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+
+		err = p.send(ctx, event)
+		if err == nil {
+			return
+		}
+		p.log.Info(fmt.Sprintf("Delivering event %s failed: %s", event.ID, err.Error()), "attempt", attempt+1)
+	}
+
+	p.writeDeadLetter(event, err)
+}
+
+func (p *Publisher) send(ctx context.Context, event Event) error {
+	req, err := p.buildRequest(event)
+	if err != nil {
+		return errors.Wrap(err, "while building request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	rsp, err := p.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "while sending request to sink")
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < http.StatusOK || rsp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("invalid response from %s, code: %d", req.URL, rsp.StatusCode)
+	}
+
+	return nil
+}
+
+func (p *Publisher) buildRequest(event Event) (*http.Request, error) {
+	if p.cfg.Mode == ModeStructured {
+		return p.buildStructuredRequest(event)
+	}
+
+	return p.buildBinaryRequest(event)
+}
+
+func (p *Publisher) buildBinaryRequest(event Event) (*http.Request, error) {
+	body, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshalling event data")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.cfg.SinkURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", dataContentType)
+	req.Header.Set("ce-specversion", SpecVersion)
+	req.Header.Set("ce-id", event.ID)
+	req.Header.Set("ce-source", p.cfg.Source)
+	req.Header.Set("ce-type", event.Type)
+	req.Header.Set("ce-time", event.Time.UTC().Format(time.RFC3339))
+	if event.Subject != "" {
+		req.Header.Set("ce-subject", event.Subject)
+	}
+
+	return req, nil
+}
+
+func (p *Publisher) buildStructuredRequest(event Event) (*http.Request, error) {
+	body, err := json.Marshal(p.toStructured(event))
+	if err != nil {
+		return nil, errors.Wrap(err, "while marshalling event")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.cfg.SinkURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", structuredContentType)
+
+	return req, nil
+}
+
+func (p *Publisher) toStructured(event Event) structuredEvent {
+	return structuredEvent{
+		SpecVersion:     SpecVersion,
+		ID:              event.ID,
+		Source:          p.cfg.Source,
+		Type:            event.Type,
+		Subject:         event.Subject,
+		Time:            event.Time.UTC().Format(time.RFC3339),
+		DataContentType: dataContentType,
+		Data:            event.Data,
+	}
+}
+
+func (p *Publisher) writeDeadLetter(event Event, err error) {
+	payload, marshalErr := json.Marshal(p.toStructured(event))
+	if marshalErr != nil {
+		payload = []byte(fmt.Sprintf("%+v", event))
+	}
+
+	p.deadLetter.Error(err, "Event not delivered", "id", event.ID, "type", event.Type, "subject", event.Subject, "event", string(payload))
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
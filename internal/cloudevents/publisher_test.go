@@ -0,0 +1,266 @@
+package cloudevents_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/rafter/internal/cloudevents"
+	"github.com/onsi/gomega"
+)
+
+func TestPublisher_Deliver(t *testing.T) {
+	t.Run("Binary", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newTestSink(0)
+		defer sink.Close()
+		log := &testLogger{}
+
+		publisher, err := cloudevents.New(fixConfig(sink.URL, cloudevents.ModeBinary), log, http.DefaultClient)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// When
+		publisher.Deliver(context.TODO(), fixEvent())
+
+		// Then
+		g.Expect(sink.requests).To(gomega.HaveLen(1))
+		req := sink.requests[0]
+		g.Expect(req.header.Get("ce-specversion")).To(gomega.Equal(cloudevents.SpecVersion))
+		g.Expect(req.header.Get("ce-id")).To(gomega.Equal("id"))
+		g.Expect(req.header.Get("ce-type")).To(gomega.Equal("io.kyma.rafter.asset.ready"))
+		g.Expect(req.header.Get("ce-source")).To(gomega.Equal("test"))
+		g.Expect(req.header.Get("ce-subject")).To(gomega.Equal("default/test-asset"))
+		g.Expect(req.header.Get("Content-Type")).To(gomega.Equal("application/json"))
+		g.Expect(req.body).To(gomega.MatchJSON(`{"phase":"Ready"}`))
+		g.Expect(log.errors).To(gomega.BeEmpty())
+	})
+
+	t.Run("Structured", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newTestSink(0)
+		defer sink.Close()
+		log := &testLogger{}
+
+		publisher, err := cloudevents.New(fixConfig(sink.URL, cloudevents.ModeStructured), log, http.DefaultClient)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// When
+		publisher.Deliver(context.TODO(), fixEvent())
+
+		// Then
+		g.Expect(sink.requests).To(gomega.HaveLen(1))
+		req := sink.requests[0]
+		g.Expect(req.header.Get("Content-Type")).To(gomega.Equal("application/cloudevents+json"))
+		event := map[string]interface{}{}
+		g.Expect(json.Unmarshal([]byte(req.body), &event)).To(gomega.Succeed())
+		g.Expect(event).To(gomega.HaveKeyWithValue("specversion", cloudevents.SpecVersion))
+		g.Expect(event).To(gomega.HaveKeyWithValue("type", "io.kyma.rafter.asset.ready"))
+		g.Expect(event).To(gomega.HaveKeyWithValue("source", "test"))
+		g.Expect(event).To(gomega.HaveKeyWithValue("data", map[string]interface{}{"phase": "Ready"}))
+		g.Expect(log.errors).To(gomega.BeEmpty())
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newTestSink(2)
+		defer sink.Close()
+		log := &testLogger{}
+
+		publisher, err := cloudevents.New(fixConfig(sink.URL, cloudevents.ModeBinary), log, http.DefaultClient)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// When
+		publisher.Deliver(context.TODO(), fixEvent())
+
+		// Then
+		g.Expect(sink.requests).To(gomega.HaveLen(3))
+		g.Expect(log.errors).To(gomega.BeEmpty())
+	})
+
+	t.Run("DeadLetter", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newTestSink(10)
+		defer sink.Close()
+		log := &testLogger{}
+
+		publisher, err := cloudevents.New(fixConfig(sink.URL, cloudevents.ModeBinary), log, http.DefaultClient)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// When
+		publisher.Deliver(context.TODO(), fixEvent())
+
+		// Then
+		g.Expect(sink.requests).To(gomega.HaveLen(4))
+		g.Expect(log.errors).To(gomega.ConsistOf("dead-letter"))
+	})
+}
+
+func TestPublisher_Emit(t *testing.T) {
+	t.Run("Delivered", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		sink := newTestSink(0)
+		defer sink.Close()
+		stop := make(chan struct{})
+		defer close(stop)
+
+		publisher, err := cloudevents.New(fixConfig(sink.URL, cloudevents.ModeBinary), &testLogger{}, http.DefaultClient)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		go publisher.Start(stop)
+
+		// When
+		publisher.Emit(fixEvent())
+
+		// Then
+		g.Eventually(sink.count).Should(gomega.Equal(1))
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		log := &testLogger{}
+		cfg := fixConfig("http://localhost", cloudevents.ModeBinary)
+		cfg.QueueSize = 1
+
+		publisher, err := cloudevents.New(cfg, log, http.DefaultClient)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// When
+		publisher.Emit(fixEvent())
+		publisher.Emit(fixEvent())
+
+		// Then
+		g.Expect(log.errors).To(gomega.ConsistOf("dead-letter"))
+	})
+}
+
+func TestNew(t *testing.T) {
+	for testName, testCase := range map[string]cloudevents.Config{
+		"MissingSinkURL": fixConfig("", cloudevents.ModeBinary),
+		"InvalidMode":    fixConfig("http://localhost", "batch"),
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			_, err := cloudevents.New(testCase, &testLogger{}, http.DefaultClient)
+
+			// Then
+			g.Expect(err).To(gomega.HaveOccurred())
+		})
+	}
+}
+
+func TestType(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+
+	// When
+	result := cloudevents.Type("ClusterBucket", "Deleted")
+
+	// Then
+	g.Expect(result).To(gomega.Equal("io.kyma.rafter.clusterbucket.deleted"))
+}
+
+func fixConfig(sinkURL, mode string) cloudevents.Config {
+	return cloudevents.Config{
+		Enabled:       true,
+		SinkURL:       sinkURL,
+		Mode:          mode,
+		Source:        "test",
+		Timeout:       time.Second,
+		MaxRetries:    3,
+		RetryInterval: time.Millisecond,
+		QueueSize:     10,
+	}
+}
+
+func fixEvent() cloudevents.Event {
+	return cloudevents.Event{
+		ID:      "id",
+		Type:    cloudevents.Type("Asset", "Ready"),
+		Subject: "default/test-asset",
+		Time:    time.Now(),
+		Data:    map[string]string{"phase": "Ready"},
+	}
+}
+
+type testRequest struct {
+	header http.Header
+	body   string
+}
+
+type testSink struct {
+	*httptest.Server
+	mux      sync.Mutex
+	failures int
+	requests []testRequest
+}
+
+// newTestSink starts a sink responding with an error to the given number of first requests
+func newTestSink(failures int) *testSink {
+	sink := &testSink{failures: failures}
+	sink.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		sink.mux.Lock()
+		defer sink.mux.Unlock()
+		sink.requests = append(sink.requests, testRequest{header: r.Header, body: string(body)})
+		if len(sink.requests) <= sink.failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	return sink
+}
+
+func (s *testSink) count() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.requests)
+}
+
+type testLogger struct {
+	name   string
+	errors []string
+	root   *testLogger
+}
+
+var _ logr.Logger = &testLogger{}
+
+func (l *testLogger) Info(msg string, keysAndValues ...interface{}) {}
+
+func (l *testLogger) Enabled() bool { return true }
+
+func (l *testLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	root := l
+	if l.root != nil {
+		root = l.root
+	}
+	root.errors = append(root.errors, l.name)
+}
+
+func (l *testLogger) V(level int) logr.InfoLogger { return l }
+
+func (l *testLogger) WithValues(keysAndValues ...interface{}) logr.Logger { return l }
+
+func (l *testLogger) WithName(name string) logr.Logger {
+	root := l
+	if l.root != nil {
+		root = l.root
+	}
+	return &testLogger{name: name, root: root}
+}
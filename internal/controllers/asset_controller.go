@@ -2,23 +2,34 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/cloudevents"
+	"github.com/kyma-project/rafter/internal/expiry"
 	"github.com/kyma-project/rafter/internal/finalizer"
 	"github.com/kyma-project/rafter/internal/handler/asset"
 	"github.com/kyma-project/rafter/internal/loader"
 	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/storeclass"
 	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const deleteAssetFinalizerName = "deleteasset.finalizers.rafter.kyma-project.io"
@@ -27,22 +38,30 @@ const deleteAssetFinalizerName = "deleteasset.finalizers.rafter.kyma-project.io"
 type AssetReconciler struct {
 	client.Client
 	Log logr.Logger
+	shutdown
 
 	cacheSynchronizer       func(stop <-chan struct{}) bool
 	recorder                record.EventRecorder
 	relistInterval          time.Duration
+	retryPolicy             asset.RetryPolicy
 	maxConcurrentReconciles int
 	store                   store.Store
+	storeClasses            storeclass.Provider
 	loader                  loader.Loader
 	finalizer               finalizer.Finalizer
 	validator               assethook.Validator
 	mutator                 assethook.Mutator
 	metadataExtractor       assethook.MetadataExtractor
+	notifier                assethook.Notifier
+	events                  cloudevents.Emitter
 }
 
 type AssetConfig struct {
 	MaxConcurrentReconciles int           `envconfig:"default=1"`
 	RelistInterval          time.Duration `envconfig:"default=30s"`
+	RetryInitialInterval    time.Duration `envconfig:"default=5s"`
+	RetryMaxInterval        time.Duration `envconfig:"default=10m"`
+	RetryMaxAttempts        int           `envconfig:"default=0"`
 }
 
 func NewAsset(config AssetConfig, log logr.Logger, di *Container) *AssetReconciler {
@@ -54,12 +73,20 @@ func NewAsset(config AssetConfig, log logr.Logger, di *Container) *AssetReconcil
 		Log:               log,
 		recorder:          di.Manager.GetEventRecorderFor("asset-controller"),
 		relistInterval:    config.RelistInterval,
+		retryPolicy: asset.RetryPolicy{
+			InitialInterval: config.RetryInitialInterval,
+			MaxInterval:     config.RetryMaxInterval,
+			MaxAttempts:     config.RetryMaxAttempts,
+		},
 		store:             di.Store,
+		storeClasses:      di.StoreClasses,
 		loader:            di.Loader,
 		finalizer:         deleteFinalizer,
 		validator:         di.Validator,
 		mutator:           di.Mutator,
 		metadataExtractor: di.Extractor,
+		notifier:          di.Notifier,
+		events:            di.Events,
 	}
 }
 
@@ -68,10 +95,15 @@ func NewAsset(config AssetConfig, log logr.Logger, di *Container) *AssetReconcil
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=assets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets/status,verbs=get;list
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets/status,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *AssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := r.shutdown.context()
 	defer cancel()
 
 	if err := r.appendFinalizer(ctx, request.NamespacedName); err != nil {
@@ -88,8 +120,38 @@ func (r *AssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
+	expirationTime := expiry.Time(instance.CreationTimestamp, instance.Spec.TTL, instance.Spec.ExpiresAt)
+	if instance.DeletionTimestamp.IsZero() && expiry.IsExpired(expirationTime, time.Now()) {
+		return ctrl.Result{}, deleteExpired(ctx, r.Client, r.recorder, instance, assetstorev1beta1.AssetExpired.String(), assetstorev1beta1.AssetExpired.Message(), expirationTime)
+	}
+
 	assetLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName(), "namespace", instance.GetNamespace())
-	commonHandler := asset.New(assetLogger, r.recorder, r.store, r.loader, r.findBucket, r.validator, r.mutator, r.metadataExtractor, r.relistInterval)
+	findBucket := r.bucketFinder(instance)
+	assetStore, err := r.findStore(ctx, findBucket, instance.Namespace, instance.Spec.BucketRef.Name)
+	if err != nil {
+		if !instance.DeletionTimestamp.IsZero() && isStoreMissing(err) {
+			// the remote content can't be reached anymore, so it is left behind instead of blocking the deletion
+			reason := assetstorev1beta1.AssetRemoteDeletionSkipped
+			r.recorder.Eventf(instance, "Warning", reason.String(), reason.Message(), err.Error())
+			return ctrl.Result{}, r.removeFinalizer(ctx, request.NamespacedName)
+		}
+		if isBucketNotAllowed(err) {
+			// the Asset is requeued once the allow-list of the ClusterBucket changes, relisting covers missed events
+			reason := assetstorev1beta1.AssetBucketNotAllowed
+			r.recorder.Eventf(instance, "Warning", reason.String(), reason.Message(), instance.Spec.BucketRef.Name)
+			status := &assetstorev1beta1.CommonAssetStatus{
+				LastHeartbeatTime:  v1.Now(),
+				ObservedGeneration: instance.Generation,
+				Phase:              assetstorev1beta1.AssetFailed,
+				Reason:             reason,
+				Message:            fmt.Sprintf(reason.Message(), instance.Spec.BucketRef.Name),
+			}
+			return ctrl.Result{RequeueAfter: r.relistInterval}, r.updateStatus(ctx, request.NamespacedName, status)
+		}
+		return ctrl.Result{}, errors.Wrap(err, "while resolving store")
+	}
+
+	commonHandler := asset.New(assetLogger, r.recorder, assetStore, r.loader, findBucket, newSecretKeyFinder(r.Client), newServiceVersionFinder(r.Client), newConfigMapVersionFinder(r.Client), r.validator, r.mutator, r.metadataExtractor, r.notifier, r.relistInterval, r.retryPolicy)
 	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, instance.Spec.CommonAssetSpec, instance.Status.CommonAssetStatus)
 	if updateErr := r.updateStatus(ctx, request.NamespacedName, commonStatus); updateErr != nil {
 		finalErr := updateErr
@@ -102,12 +164,14 @@ func (r *AssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
-	if err := r.removeFinalizer(ctx, request.NamespacedName); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "while removing finalizer")
+	if !isDeletionNotificationPending(commonStatus) {
+		if err := r.removeFinalizer(ctx, request.NamespacedName); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "while removing finalizer")
+		}
 	}
 
 	return ctrl.Result{
-		RequeueAfter: r.relistInterval,
+		RequeueAfter: r.requeueAfter(instance.Status.CommonAssetStatus, commonStatus),
 	}, nil
 }
 
@@ -127,14 +191,19 @@ func (r *AssetReconciler) appendFinalizer(ctx context.Context, namespacedName ty
 
 func (r *AssetReconciler) removeFinalizer(ctx context.Context, namespacedName types.NamespacedName) error {
 	updateFnc := func(instance *assetstorev1beta1.Asset) error {
-		if instance.DeletionTimestamp.IsZero() {
+		if instance.DeletionTimestamp.IsZero() || !r.finalizer.IsDefinedIn(instance) {
 			return nil
 		}
 
 		copy := instance.DeepCopy()
 		r.finalizer.DeleteFrom(copy)
 
-		return r.Update(ctx, copy)
+		if err := r.Update(ctx, copy); err != nil {
+			return err
+		}
+		emitEvent(r.events, "Asset", copy, deletedEventAction, copy.Status)
+
+		return nil
 	}
 
 	return r.update(ctx, namespacedName, updateFnc)
@@ -149,7 +218,12 @@ func (r *AssetReconciler) updateStatus(ctx context.Context, namespacedName types
 		copy := instance.DeepCopy()
 		copy.Status.CommonAssetStatus = *commonStatus
 
-		return r.Status().Update(ctx, copy)
+		if err := r.Status().Update(ctx, copy); err != nil {
+			return err
+		}
+		emitPhaseEvent(r.events, "Asset", copy, string(instance.Status.Phase), string(commonStatus.Phase), copy.Status)
+
+		return nil
 	}
 
 	return r.update(ctx, namespacedName, updateFnc)
@@ -161,7 +235,21 @@ func (r *AssetReconciler) isStatusUnchanged(instance *assetstorev1beta1.Asset, n
 	return newStatus == nil ||
 		currentStatus.ObservedGeneration == newStatus.ObservedGeneration &&
 			currentStatus.Phase == newStatus.Phase &&
-			currentStatus.Reason == newStatus.Reason
+			currentStatus.Reason == newStatus.Reason &&
+			currentStatus.LastResyncRequestedAt == newStatus.LastResyncRequestedAt &&
+			currentStatus.RetryCount == newStatus.RetryCount &&
+			currentStatus.WebhooksFingerprint == newStatus.WebhooksFingerprint &&
+			currentStatus.ExpirationTime.Equal(newStatus.ExpirationTime) &&
+			equality.Semantic.DeepEqual(currentStatus.PendingNotification, newStatus.PendingNotification)
+}
+
+// requeueAfter shortens the relist interval when the next retry of a failed asset or a notification, or the expiration is due earlier
+func (r *AssetReconciler) requeueAfter(currentStatus assetstorev1beta1.CommonAssetStatus, newStatus *assetstorev1beta1.CommonAssetStatus) time.Duration {
+	if newStatus != nil {
+		currentStatus = *newStatus
+	}
+
+	return requeueBefore(r.relistInterval, currentStatus.NextRetryTime, currentStatus.ExpirationTime, nextNotificationTime(currentStatus))
 }
 
 func (r *AssetReconciler) update(ctx context.Context, namespacedName types.NamespacedName, updateFnc func(instance *assetstorev1beta1.Asset) error) error {
@@ -188,14 +276,40 @@ func (r *AssetReconciler) update(ctx context.Context, namespacedName types.Names
 }
 
 func (r *AssetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(&assetstorev1beta1.Asset{}, bucketRefNameField, indexAssetBucketRefName); err != nil {
+		return errors.Wrapf(err, "while indexing Assets by %s", bucketRefNameField)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(&assetstorev1beta1.Asset{}, configMapSourceField, indexAssetConfigMapSource); err != nil {
+		return errors.Wrapf(err, "while indexing Assets by %s", configMapSourceField)
+	}
+	if err := metrics.Registry.Register(&assetPhaseCollector{reader: mgr.GetClient()}); err != nil {
+		return errors.Wrap(err, "while registering Assets metrics")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&assetstorev1beta1.Asset{}).
+		Watches(&source.Kind{Type: &assetstorev1beta1.Bucket{}}, newBucketEventHandler(r.findAssetsForBucket)).
+		Watches(&source.Kind{Type: &assetstorev1beta1.ClusterBucket{}}, newBucketEventHandler(r.findAssetsForClusterBucket)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.findAssetsForConfigMap)}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.maxConcurrentReconciles,
 		}).
 		Complete(r)
 }
 
+func (r *AssetReconciler) findStore(ctx context.Context, findBucket asset.FindBucketStatus, namespace, name string) (store.Store, error) {
+	bucketStatus, isReady, err := findBucket(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !isReady {
+		return r.store, nil
+	}
+
+	assetStore, _, err := resolveStore(ctx, r.store, r.storeClasses, bucketStatus.StoreClassName)
+	return assetStore, err
+}
+
 func (r *AssetReconciler) findBucket(ctx context.Context, namespace, name string) (*assetstorev1beta1.CommonBucketStatus, bool, error) {
 	instance := &assetstorev1beta1.Bucket{}
 
@@ -215,3 +329,54 @@ func (r *AssetReconciler) findBucket(ctx context.Context, namespace, name string
 
 	return &instance.Status.CommonBucketStatus, true, nil
 }
+
+func (r *AssetReconciler) findAssetsForBucket(obj handler.MapObject) []reconcile.Request {
+	instances := &assetstorev1beta1.AssetList{}
+	if err := r.List(context.Background(), instances, client.InNamespace(obj.Meta.GetNamespace()), client.MatchingFields{bucketRefNameField: obj.Meta.GetName()}); err != nil {
+		r.Log.Error(err, "Unable to list Assets for Bucket", "name", obj.Meta.GetName(), "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, item := range instances.Items {
+		if item.Spec.BucketRef.Kind == assetstorev1beta1.AssetBucketRefKindClusterBucket {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+	}
+
+	return requests
+}
+
+func (r *AssetReconciler) findAssetsForClusterBucket(obj handler.MapObject) []reconcile.Request {
+	instances := &assetstorev1beta1.AssetList{}
+	if err := r.List(context.Background(), instances, client.MatchingFields{bucketRefNameField: obj.Meta.GetName()}); err != nil {
+		r.Log.Error(err, "Unable to list Assets for ClusterBucket", "name", obj.Meta.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, item := range instances.Items {
+		if item.Spec.BucketRef.Kind != assetstorev1beta1.AssetBucketRefKindClusterBucket {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+	}
+
+	return requests
+}
+
+func (r *AssetReconciler) findAssetsForConfigMap(obj handler.MapObject) []reconcile.Request {
+	instances := &assetstorev1beta1.AssetList{}
+	if err := r.List(context.Background(), instances, client.MatchingFields{configMapSourceField: configMapKey(obj)}); err != nil {
+		r.Log.Error(err, "Unable to list Assets for ConfigMap", "name", obj.Meta.GetName(), "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, item := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+	}
+
+	return requests
+}
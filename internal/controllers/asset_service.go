@@ -68,6 +68,12 @@ func (s *assetService) Update(ctx context.Context, commonAsset assetgroup.Common
 
 	updated := instance.DeepCopy()
 	updated.Spec.CommonAssetSpec = commonAsset.Spec
+	if resyncRequestedAt, ok := commonAsset.Annotations[v1beta1.ResyncRequestedAtAnnotation]; ok {
+		if updated.Annotations == nil {
+			updated.Annotations = make(map[string]string)
+		}
+		updated.Annotations[v1beta1.ResyncRequestedAtAnnotation] = resyncRequestedAt
+	}
 
 	return s.client.Update(ctx, updated)
 }
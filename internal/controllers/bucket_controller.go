@@ -2,12 +2,15 @@ package controllers
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyma-project/rafter/internal/cloudevents"
 	"github.com/kyma-project/rafter/internal/finalizer"
 	"github.com/kyma-project/rafter/internal/handler/bucket"
 	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/storeclass"
 	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,19 +34,28 @@ type BucketReconciler struct {
 	relistInterval          time.Duration
 	finalizer               finalizer.Finalizer
 	store                   store.Store
+	storeClasses            storeclass.Provider
 	externalEndpoint        string
 	maxConcurrentReconciles int
+	events                  cloudevents.Emitter
+	adoptableRemoteNames    *regexp.Regexp
 }
 
 type BucketConfig struct {
 	MaxConcurrentReconciles int           `envconfig:"default=1"`
 	RelistInterval          time.Duration `envconfig:"default=30s"`
 	ExternalEndpoint        string        `envconfig:"-"`
+	AdoptableRemoteNames    string        `envconfig:"optional"`
 }
 
-func NewBucket(config BucketConfig, log logr.Logger, di *Container) *BucketReconciler {
+func NewBucket(config BucketConfig, log logr.Logger, di *Container) (*BucketReconciler, error) {
 	deleteFinalizer := finalizer.New(deleteBucketFinalizerName)
 
+	adoptableRemoteNames, err := compileAdoptableRemoteNames(config.AdoptableRemoteNames)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BucketReconciler{
 		Client:                  di.Manager.GetClient(),
 		cacheSynchronizer:       di.Manager.GetCache().WaitForCacheSync,
@@ -51,15 +63,19 @@ func NewBucket(config BucketConfig, log logr.Logger, di *Container) *BucketRecon
 		recorder:                di.Manager.GetEventRecorderFor("bucket-controller"),
 		relistInterval:          config.RelistInterval,
 		store:                   di.Store,
+		storeClasses:            di.StoreClasses,
 		finalizer:               deleteFinalizer,
 		externalEndpoint:        config.ExternalEndpoint,
 		maxConcurrentReconciles: config.MaxConcurrentReconciles,
-	}
+		events:                  di.Events,
+		adoptableRemoteNames:    adoptableRemoteNames,
+	}, nil
 }
 
 // Reconcile reads that state of the cluster for a Bucket object and makes changes based on the state read
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=buckets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=storeclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *BucketReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
@@ -81,8 +97,22 @@ func (r *BucketReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error)
 	}
 
 	bucketLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName(), "namespace", instance.GetNamespace())
-	commonHandler := bucket.New(bucketLogger, r.recorder, r.store, r.externalEndpoint, r.relistInterval)
-	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, instance.Spec.CommonBucketSpec, instance.Status.CommonBucketStatus)
+	bucketStore, storeClass, err := resolveStore(ctx, r.store, r.storeClasses, bucket.StoreClassName(instance.Spec.CommonBucketSpec, instance.Status.CommonBucketStatus))
+	if err != nil {
+		if !instance.DeletionTimestamp.IsZero() && isStoreMissing(err) {
+			// the remote bucket can't be reached anymore, so it is left behind instead of blocking the deletion
+			reason := assetstorev1beta1.BucketRemoteDeletionSkipped
+			r.recorder.Eventf(instance, "Warning", reason.String(), reason.Message(), instance.Status.RemoteName, err.Error())
+			return ctrl.Result{}, r.removeFinalizer(ctx, request.NamespacedName)
+		}
+		reason := assetstorev1beta1.BucketStoreClassInvalid
+		r.recorder.Eventf(instance, "Warning", reason.String(), reason.Message(), err.Error())
+		return ctrl.Result{}, errors.Wrap(err, "while resolving store")
+	}
+	spec, externalEndpoint := applyStoreClass(instance.Spec.CommonBucketSpec, r.externalEndpoint, storeClass)
+
+	commonHandler := bucket.New(bucketLogger, r.recorder, bucketStore, newRemoteNameUsersFinder(r.Client), externalEndpoint, r.relistInterval, r.adoptableRemoteNames)
+	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, spec, instance.Status.CommonBucketStatus)
 	if updateErr := r.updateStatus(ctx, request.NamespacedName, commonStatus); updateErr != nil {
 		finalErr := updateErr
 		if err != nil {
@@ -119,14 +149,19 @@ func (r *BucketReconciler) appendFinalizer(ctx context.Context, namespacedName t
 
 func (r *BucketReconciler) removeFinalizer(ctx context.Context, namespacedName types.NamespacedName) error {
 	updateFnc := func(instance *assetstorev1beta1.Bucket) error {
-		if instance.DeletionTimestamp.IsZero() {
+		if instance.DeletionTimestamp.IsZero() || !r.finalizer.IsDefinedIn(instance) {
 			return nil
 		}
 
 		copy := instance.DeepCopy()
 		r.finalizer.DeleteFrom(copy)
 
-		return r.Update(ctx, copy)
+		if err := r.Update(ctx, copy); err != nil {
+			return err
+		}
+		emitEvent(r.events, "Bucket", copy, deletedEventAction, copy.Status)
+
+		return nil
 	}
 
 	return r.update(ctx, namespacedName, updateFnc)
@@ -141,7 +176,12 @@ func (r *BucketReconciler) updateStatus(ctx context.Context, namespacedName type
 		copy := instance.DeepCopy()
 		copy.Status.CommonBucketStatus = *commonStatus
 
-		return r.Status().Update(ctx, copy)
+		if err := r.Status().Update(ctx, copy); err != nil {
+			return err
+		}
+		emitPhaseEvent(r.events, "Bucket", copy, string(instance.Status.Phase), string(commonStatus.Phase), copy.Status)
+
+		return nil
 	}
 
 	return r.update(ctx, namespacedName, updateFnc)
@@ -153,7 +193,9 @@ func (r *BucketReconciler) isStatusUnchanged(instance *assetstorev1beta1.Bucket,
 	return newStatus == nil ||
 		currentStatus.ObservedGeneration == newStatus.ObservedGeneration &&
 			currentStatus.Phase == newStatus.Phase &&
-			currentStatus.Reason == newStatus.Reason
+			currentStatus.Reason == newStatus.Reason &&
+			currentStatus.DeletionPolicy == newStatus.DeletionPolicy &&
+			currentStatus.Adopted == newStatus.Adopted
 }
 
 func (r *BucketReconciler) update(ctx context.Context, namespacedName types.NamespacedName, updateFnc func(instance *assetstorev1beta1.Bucket) error) error {
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-project/rafter/internal/handler/asset"
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// bucketFinder resolves the bucket referenced by the Asset, which can be a ClusterBucket shared with allowed namespaces
+func (r *AssetReconciler) bucketFinder(instance *assetstorev1beta1.Asset) asset.FindBucketStatus {
+	if instance.Spec.BucketRef.Kind != assetstorev1beta1.AssetBucketRefKindClusterBucket {
+		return r.findBucket
+	}
+
+	// content of a deleted Asset is removed even if its namespace is no longer allowed
+	checkAllowed := instance.DeletionTimestamp.IsZero()
+	return func(ctx context.Context, namespace, name string) (*assetstorev1beta1.CommonBucketStatus, bool, error) {
+		return r.findClusterBucket(ctx, namespace, name, checkAllowed)
+	}
+}
+
+func (r *AssetReconciler) findClusterBucket(ctx context.Context, namespace, name string, checkAllowed bool) (*assetstorev1beta1.CommonBucketStatus, bool, error) {
+	instance := &assetstorev1beta1.ClusterBucket{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, instance); err != nil {
+		if apiErrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if checkAllowed && !isNamespaceAllowed(instance.Annotations, namespace) {
+		return nil, false, &bucketNotAllowedError{namespace: namespace, name: name}
+	}
+	if instance.Status.Phase != assetstorev1beta1.BucketReady {
+		return nil, false, nil
+	}
+
+	return &instance.Status.CommonBucketStatus, true, nil
+}
+
+// bucketNotAllowedError is returned when the namespace is missing from the allow-list of the ClusterBucket
+type bucketNotAllowedError struct {
+	namespace, name string
+}
+
+func (e *bucketNotAllowedError) Error() string {
+	return fmt.Sprintf("namespace %s is not allowed to use ClusterBucket %s", e.namespace, e.name)
+}
+
+func isBucketNotAllowed(err error) bool {
+	_, ok := errors.Cause(err).(*bucketNotAllowedError)
+	return ok
+}
+
+// isNamespaceAllowed checks the allow-list annotation of a ClusterBucket
+func isNamespaceAllowed(annotations map[string]string, namespace string) bool {
+	for _, allowed := range strings.Split(annotations[assetstorev1beta1.AllowedNamespacesAnnotation], ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+
+	return false
+}
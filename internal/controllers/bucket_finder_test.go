@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/finalizer"
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestAssetReconciler_FindClusterBucket(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		allowedNamespaces string
+		checkAllowed      bool
+		expectErr         bool
+		expectReady       bool
+	}{
+		"Allowed":           {allowedNamespaces: "other-ns, test-ns", checkAllowed: true, expectReady: true},
+		"AllNamespaces":     {allowedNamespaces: "*", checkAllowed: true, expectReady: true},
+		"NotAllowed":        {allowedNamespaces: "other-ns", checkAllowed: true, expectErr: true},
+		"NoAnnotation":      {checkAllowed: true, expectErr: true},
+		"DeletedNotChecked": {allowedNamespaces: "other-ns", expectReady: true},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			ctx := context.TODO()
+			scheme := runtime.NewScheme()
+			g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+			bucket := &assetstorev1beta1.ClusterBucket{
+				ObjectMeta: v1.ObjectMeta{Name: "test-bucket"},
+				Status: assetstorev1beta1.ClusterBucketStatus{
+					CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{Phase: assetstorev1beta1.BucketReady},
+				},
+			}
+			if testCase.allowedNamespaces != "" {
+				bucket.Annotations = map[string]string{assetstorev1beta1.AllowedNamespacesAnnotation: testCase.allowedNamespaces}
+			}
+			reconciler := &AssetReconciler{Client: fake.NewFakeClientWithScheme(scheme, bucket)}
+
+			// When
+			status, isReady, err := reconciler.findClusterBucket(ctx, "test-ns", "test-bucket", testCase.checkAllowed)
+
+			// Then
+			if testCase.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(isBucketNotAllowed(err)).To(gomega.BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(isReady).To(gomega.Equal(testCase.expectReady))
+			g.Expect(status).ToNot(gomega.BeNil())
+		})
+	}
+}
+
+func TestAssetReconciler_Reconcile_BucketNotAllowed(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	bucket := &assetstorev1beta1.ClusterBucket{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test-bucket",
+			Annotations: map[string]string{assetstorev1beta1.AllowedNamespacesAnnotation: "other-ns"},
+		},
+		Status: assetstorev1beta1.ClusterBucketStatus{
+			CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{Phase: assetstorev1beta1.BucketReady},
+		},
+	}
+	asset := &assetstorev1beta1.Asset{
+		ObjectMeta: v1.ObjectMeta{Name: "test-asset", Namespace: "test-ns", Generation: 1},
+		Spec: assetstorev1beta1.AssetSpec{
+			CommonAssetSpec: assetstorev1beta1.CommonAssetSpec{
+				BucketRef: assetstorev1beta1.AssetBucketRef{Name: bucket.Name, Kind: assetstorev1beta1.AssetBucketRefKindClusterBucket},
+			},
+		},
+		Status: assetstorev1beta1.AssetStatus{
+			CommonAssetStatus: assetstorev1beta1.CommonAssetStatus{Phase: assetstorev1beta1.AssetPending},
+		},
+	}
+	k8sClient := fake.NewFakeClientWithScheme(scheme, bucket, asset)
+	recorder := record.NewFakeRecorder(100)
+
+	reconciler := &AssetReconciler{
+		Client:            k8sClient,
+		cacheSynchronizer: func(stop <-chan struct{}) bool { return true },
+		Log:               log.Log,
+		recorder:          recorder,
+		relistInterval:    time.Hour,
+		finalizer:         finalizer.New("test"),
+	}
+
+	// When
+	_, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: asset.Namespace, Name: asset.Name}})
+
+	// Then
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	result := &assetstorev1beta1.Asset{}
+	g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: asset.Namespace, Name: asset.Name}, result)).To(gomega.Succeed())
+	g.Expect(result.Status.Phase).To(gomega.Equal(assetstorev1beta1.AssetFailed))
+	g.Expect(result.Status.Reason).To(gomega.Equal(assetstorev1beta1.AssetBucketNotAllowed))
+	g.Expect(result.Status.ObservedGeneration).To(gomega.Equal(int64(1)))
+	g.Expect(recorder.Events).To(gomega.Receive(gomega.HavePrefix("Warning BucketNotAllowed")))
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const bucketRefNameField = "spec.bucketRef.name"
+
+func indexAssetBucketRefName(obj runtime.Object) []string {
+	instance, ok := obj.(*assetstorev1beta1.Asset)
+	if !ok || instance.Spec.BucketRef.Name == "" {
+		return nil
+	}
+
+	return []string{instance.Spec.BucketRef.Name}
+}
+
+func indexClusterAssetBucketRefName(obj runtime.Object) []string {
+	instance, ok := obj.(*assetstorev1beta1.ClusterAsset)
+	if !ok || instance.Spec.BucketRef.Name == "" {
+		return nil
+	}
+
+	return []string{instance.Spec.BucketRef.Name}
+}
+
+// newBucketEventHandler enqueues requests of dependent assets when a bucket becomes ready, changes the namespaces
+// allowed to use it or is deleted
+func newBucketEventHandler(toRequests handler.ToRequestsFunc) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			becameReady := bucketPhase(e.ObjectOld) != assetstorev1beta1.BucketReady && bucketPhase(e.ObjectNew) == assetstorev1beta1.BucketReady
+			if !becameReady && !isAllowedNamespacesChanged(e.MetaOld, e.MetaNew) {
+				return
+			}
+
+			enqueue(q, toRequests(handler.MapObject{Meta: e.MetaNew, Object: e.ObjectNew}))
+		},
+		DeleteFunc: func(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, toRequests(handler.MapObject{Meta: e.Meta, Object: e.Object}))
+		},
+	}
+}
+
+func isAllowedNamespacesChanged(old, new metav1.Object) bool {
+	return old.GetAnnotations()[assetstorev1beta1.AllowedNamespacesAnnotation] != new.GetAnnotations()[assetstorev1beta1.AllowedNamespacesAnnotation]
+}
+
+func bucketPhase(obj runtime.Object) assetstorev1beta1.BucketPhase {
+	switch instance := obj.(type) {
+	case *assetstorev1beta1.Bucket:
+		return instance.Status.Phase
+	case *assetstorev1beta1.ClusterBucket:
+		return instance.Status.Phase
+	default:
+		return ""
+	}
+}
+
+func enqueue(q workqueue.RateLimitingInterface, requests []reconcile.Request) {
+	for _, request := range requests {
+		q.Add(request)
+	}
+}
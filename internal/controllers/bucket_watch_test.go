@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"testing"
+
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestBucketEventHandler(t *testing.T) {
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "test-ns", Name: "test-asset"}}
+	toRequests := func(obj handler.MapObject) []reconcile.Request {
+		return []reconcile.Request{request}
+	}
+
+	t.Run("BecameReady", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		old, updated := fixBucketPhase(""), fixBucketPhase(assetstorev1beta1.BucketReady)
+
+		// When
+		newBucketEventHandler(toRequests).Update(event.UpdateEvent{MetaOld: old, ObjectOld: old, MetaNew: updated, ObjectNew: updated}, queue)
+
+		// Then
+		g.Expect(queue.Len()).To(gomega.Equal(1))
+		item, _ := queue.Get()
+		g.Expect(item).To(gomega.Equal(request))
+	})
+
+	t.Run("StillReady", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		old, updated := fixBucketPhase(assetstorev1beta1.BucketReady), fixBucketPhase(assetstorev1beta1.BucketReady)
+
+		// When
+		newBucketEventHandler(toRequests).Update(event.UpdateEvent{MetaOld: old, ObjectOld: old, MetaNew: updated, ObjectNew: updated}, queue)
+
+		// Then
+		g.Expect(queue.Len()).To(gomega.BeZero())
+	})
+
+	t.Run("AllowedNamespacesChanged", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		old := fixClusterBucketPhase(assetstorev1beta1.BucketReady)
+		old.Annotations = map[string]string{assetstorev1beta1.AllowedNamespacesAnnotation: "other-ns"}
+		updated := fixClusterBucketPhase(assetstorev1beta1.BucketReady)
+		updated.Annotations = map[string]string{assetstorev1beta1.AllowedNamespacesAnnotation: "other-ns,test-ns"}
+
+		// When
+		newBucketEventHandler(toRequests).Update(event.UpdateEvent{MetaOld: old, ObjectOld: old, MetaNew: updated, ObjectNew: updated}, queue)
+
+		// Then
+		g.Expect(queue.Len()).To(gomega.Equal(1))
+		item, _ := queue.Get()
+		g.Expect(item).To(gomega.Equal(request))
+	})
+
+	t.Run("NotReady", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		old, updated := fixBucketPhase(""), fixBucketPhase(assetstorev1beta1.BucketFailed)
+
+		// When
+		newBucketEventHandler(toRequests).Update(event.UpdateEvent{MetaOld: old, ObjectOld: old, MetaNew: updated, ObjectNew: updated}, queue)
+
+		// Then
+		g.Expect(queue.Len()).To(gomega.BeZero())
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer queue.ShutDown()
+		bucket := fixBucketPhase(assetstorev1beta1.BucketReady)
+
+		// When
+		newBucketEventHandler(toRequests).Delete(event.DeleteEvent{Meta: bucket, Object: bucket}, queue)
+
+		// Then
+		g.Expect(queue.Len()).To(gomega.Equal(1))
+	})
+}
+
+func TestIndexAssetBucketRefName(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	asset := &assetstorev1beta1.Asset{
+		Spec: assetstorev1beta1.AssetSpec{
+			CommonAssetSpec: assetstorev1beta1.CommonAssetSpec{
+				BucketRef: assetstorev1beta1.AssetBucketRef{Name: "test-bucket"},
+			},
+		},
+	}
+
+	// When
+	values := indexAssetBucketRefName(asset)
+
+	// Then
+	g.Expect(values).To(gomega.ConsistOf("test-bucket"))
+	g.Expect(indexAssetBucketRefName(&assetstorev1beta1.ClusterAsset{})).To(gomega.BeEmpty())
+}
+
+func fixBucketPhase(phase assetstorev1beta1.BucketPhase) *assetstorev1beta1.Bucket {
+	return &assetstorev1beta1.Bucket{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: "test-ns",
+			Name:      "test-bucket",
+		},
+		Status: assetstorev1beta1.BucketStatus{
+			CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{
+				Phase: phase,
+			},
+		},
+	}
+}
+
+func fixClusterBucketPhase(phase assetstorev1beta1.BucketPhase) *assetstorev1beta1.ClusterBucket {
+	return &assetstorev1beta1.ClusterBucket{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-bucket",
+		},
+		Status: assetstorev1beta1.ClusterBucketStatus{
+			CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{
+				Phase: phase,
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/cloudevents"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const deletedEventAction = "deleted"
+
+// emitPhaseEvent publishes a CloudEvent with the status of the object when its phase changes
+func emitPhaseEvent(emitter cloudevents.Emitter, kind string, object v1.Object, oldPhase, newPhase string, status interface{}) {
+	if newPhase == "" || oldPhase == newPhase {
+		return
+	}
+
+	emitEvent(emitter, kind, object, newPhase, status)
+}
+
+func emitEvent(emitter cloudevents.Emitter, kind string, object v1.Object, action string, status interface{}) {
+	if emitter == nil {
+		return
+	}
+
+	emitter.Emit(cloudevents.Event{
+		ID:      string(uuid.NewUUID()),
+		Type:    cloudevents.Type(kind, action),
+		Subject: eventSubject(object),
+		Time:    time.Now(),
+		Data:    status,
+	})
+}
+
+func eventSubject(object v1.Object) string {
+	if object.GetNamespace() == "" {
+		return object.GetName()
+	}
+
+	return fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName())
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/cloudevents"
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEmitPhaseEvent(t *testing.T) {
+	t.Run("PhaseChanged", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		emitter := &testEmitter{}
+		instance := &assetstorev1beta1.Asset{ObjectMeta: v1.ObjectMeta{Namespace: "test-ns", Name: "test-asset"}}
+
+		// When
+		emitPhaseEvent(emitter, "Asset", instance, string(assetstorev1beta1.AssetPending), string(assetstorev1beta1.AssetReady), instance.Status)
+
+		// Then
+		g.Expect(emitter.events).To(gomega.HaveLen(1))
+		g.Expect(emitter.events[0].ID).ToNot(gomega.BeEmpty())
+		g.Expect(emitter.events[0].Type).To(gomega.Equal("io.kyma.rafter.asset.ready"))
+		g.Expect(emitter.events[0].Subject).To(gomega.Equal("test-ns/test-asset"))
+		g.Expect(emitter.events[0].Data).To(gomega.Equal(instance.Status))
+	})
+
+	t.Run("PhaseNotChanged", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		emitter := &testEmitter{}
+		instance := &assetstorev1beta1.Asset{ObjectMeta: v1.ObjectMeta{Namespace: "test-ns", Name: "test-asset"}}
+
+		// When
+		emitPhaseEvent(emitter, "Asset", instance, string(assetstorev1beta1.AssetReady), string(assetstorev1beta1.AssetReady), instance.Status)
+
+		// Then
+		g.Expect(emitter.events).To(gomega.BeEmpty())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Given
+		instance := &assetstorev1beta1.Asset{ObjectMeta: v1.ObjectMeta{Namespace: "test-ns", Name: "test-asset"}}
+
+		// When
+		emitPhaseEvent(nil, "Asset", instance, string(assetstorev1beta1.AssetPending), string(assetstorev1beta1.AssetReady), instance.Status)
+	})
+}
+
+func TestEmitEvent_ClusterScoped(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	emitter := &testEmitter{}
+	instance := &assetstorev1beta1.ClusterBucket{ObjectMeta: v1.ObjectMeta{Name: "test-bucket"}}
+
+	// When
+	emitEvent(emitter, "ClusterBucket", instance, deletedEventAction, instance.Status)
+
+	// Then
+	g.Expect(emitter.events).To(gomega.HaveLen(1))
+	g.Expect(emitter.events[0].Type).To(gomega.Equal("io.kyma.rafter.clusterbucket.deleted"))
+	g.Expect(emitter.events[0].Subject).To(gomega.Equal("test-bucket"))
+}
+
+type testEmitter struct {
+	events []cloudevents.Event
+}
+
+func (e *testEmitter) Emit(event cloudevents.Event) {
+	e.events = append(e.events, event)
+}
@@ -6,12 +6,17 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/cloudevents"
+	"github.com/kyma-project/rafter/internal/expiry"
 	"github.com/kyma-project/rafter/internal/finalizer"
 	"github.com/kyma-project/rafter/internal/handler/asset"
 	"github.com/kyma-project/rafter/internal/loader"
 	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/storeclass"
 	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -19,6 +24,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const deleteClusterAssetFinalizerName = "deleteclusterasset.finalizers.rafter.kyma-project.io"
@@ -27,22 +36,30 @@ const deleteClusterAssetFinalizerName = "deleteclusterasset.finalizers.rafter.ky
 type ClusterAssetReconciler struct {
 	client.Client
 	Log logr.Logger
+	shutdown
 
 	cacheSynchronizer       func(stop <-chan struct{}) bool
 	recorder                record.EventRecorder
 	relistInterval          time.Duration
+	retryPolicy             asset.RetryPolicy
 	maxConcurrentReconciles int
 	store                   store.Store
+	storeClasses            storeclass.Provider
 	loader                  loader.Loader
 	finalizer               finalizer.Finalizer
 	validator               assethook.Validator
 	mutator                 assethook.Mutator
 	metadataExtractor       assethook.MetadataExtractor
+	notifier                assethook.Notifier
+	events                  cloudevents.Emitter
 }
 
 type ClusterAssetConfig struct {
 	MaxConcurrentReconciles int           `envconfig:"default=1"`
 	RelistInterval          time.Duration `envconfig:"default=30s"`
+	RetryInitialInterval    time.Duration `envconfig:"default=5s"`
+	RetryMaxInterval        time.Duration `envconfig:"default=10m"`
+	RetryMaxAttempts        int           `envconfig:"default=0"`
 }
 
 func NewClusterAsset(config ClusterAssetConfig, log logr.Logger, di *Container) *ClusterAssetReconciler {
@@ -54,12 +71,20 @@ func NewClusterAsset(config ClusterAssetConfig, log logr.Logger, di *Container)
 		Log:               log,
 		recorder:          di.Manager.GetEventRecorderFor("clusterasset-controller"),
 		relistInterval:    config.RelistInterval,
+		retryPolicy: asset.RetryPolicy{
+			InitialInterval: config.RetryInitialInterval,
+			MaxInterval:     config.RetryMaxInterval,
+			MaxAttempts:     config.RetryMaxAttempts,
+		},
 		store:             di.Store,
+		storeClasses:      di.StoreClasses,
 		loader:            di.Loader,
 		finalizer:         deleteFinalizer,
 		validator:         di.Validator,
 		mutator:           di.Mutator,
 		metadataExtractor: di.Extractor,
+		notifier:          di.Notifier,
+		events:            di.Events,
 	}
 }
 
@@ -68,10 +93,13 @@ func NewClusterAsset(config ClusterAssetConfig, log logr.Logger, di *Container)
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterassets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rafter.kyma-project.io,resources=clusterbuckets/status,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ClusterAssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := r.shutdown.context()
 	defer cancel()
 
 	if err := r.appendFinalizer(ctx, request.NamespacedName); err != nil {
@@ -88,8 +116,24 @@ func (r *ClusterAssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, e
 		return ctrl.Result{}, err
 	}
 
+	expirationTime := expiry.Time(instance.CreationTimestamp, instance.Spec.TTL, instance.Spec.ExpiresAt)
+	if instance.DeletionTimestamp.IsZero() && expiry.IsExpired(expirationTime, time.Now()) {
+		return ctrl.Result{}, deleteExpired(ctx, r.Client, r.recorder, instance, assetstorev1beta1.AssetExpired.String(), assetstorev1beta1.AssetExpired.Message(), expirationTime)
+	}
+
 	assetLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName())
-	commonHandler := asset.New(assetLogger, r.recorder, r.store, r.loader, r.findClusterBucket, r.validator, r.mutator, r.metadataExtractor, r.relistInterval)
+	assetStore, err := r.findStore(ctx, instance.Namespace, instance.Spec.BucketRef.Name)
+	if err != nil {
+		if !instance.DeletionTimestamp.IsZero() && isStoreMissing(err) {
+			// the remote content can't be reached anymore, so it is left behind instead of blocking the deletion
+			reason := assetstorev1beta1.AssetRemoteDeletionSkipped
+			r.recorder.Eventf(instance, "Warning", reason.String(), reason.Message(), err.Error())
+			return ctrl.Result{}, r.removeFinalizer(ctx, request.NamespacedName)
+		}
+		return ctrl.Result{}, errors.Wrap(err, "while resolving store")
+	}
+
+	commonHandler := asset.New(assetLogger, r.recorder, assetStore, r.loader, r.findClusterBucket, newSecretKeyFinder(r.Client), newServiceVersionFinder(r.Client), newConfigMapVersionFinder(r.Client), r.validator, r.mutator, r.metadataExtractor, r.notifier, r.relistInterval, r.retryPolicy)
 	commonStatus, err := commonHandler.Do(ctx, time.Now(), instance, instance.Spec.CommonAssetSpec, instance.Status.CommonAssetStatus)
 	if updateErr := r.updateStatus(ctx, request.NamespacedName, commonStatus); updateErr != nil {
 		finalErr := updateErr
@@ -102,12 +146,14 @@ func (r *ClusterAssetReconciler) Reconcile(request ctrl.Request) (ctrl.Result, e
 		return ctrl.Result{}, err
 	}
 
-	if err := r.removeFinalizer(ctx, request.NamespacedName); err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "while removing finalizer")
+	if !isDeletionNotificationPending(commonStatus) {
+		if err := r.removeFinalizer(ctx, request.NamespacedName); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "while removing finalizer")
+		}
 	}
 
 	return ctrl.Result{
-		RequeueAfter: r.relistInterval,
+		RequeueAfter: r.requeueAfter(instance.Status.CommonAssetStatus, commonStatus),
 	}, nil
 }
 
@@ -127,14 +173,19 @@ func (r *ClusterAssetReconciler) appendFinalizer(ctx context.Context, namespaced
 
 func (r *ClusterAssetReconciler) removeFinalizer(ctx context.Context, namespacedName types.NamespacedName) error {
 	updateFnc := func(instance *assetstorev1beta1.ClusterAsset) error {
-		if instance.DeletionTimestamp.IsZero() {
+		if instance.DeletionTimestamp.IsZero() || !r.finalizer.IsDefinedIn(instance) {
 			return nil
 		}
 
 		copy := instance.DeepCopy()
 		r.finalizer.DeleteFrom(copy)
 
-		return r.Update(ctx, copy)
+		if err := r.Update(ctx, copy); err != nil {
+			return err
+		}
+		emitEvent(r.events, "ClusterAsset", copy, deletedEventAction, copy.Status)
+
+		return nil
 	}
 
 	return r.update(ctx, namespacedName, updateFnc)
@@ -149,7 +200,12 @@ func (r *ClusterAssetReconciler) updateStatus(ctx context.Context, namespacedNam
 		copy := instance.DeepCopy()
 		copy.Status.CommonAssetStatus = *commonStatus
 
-		return r.Status().Update(ctx, copy)
+		if err := r.Status().Update(ctx, copy); err != nil {
+			return err
+		}
+		emitPhaseEvent(r.events, "ClusterAsset", copy, string(instance.Status.Phase), string(commonStatus.Phase), copy.Status)
+
+		return nil
 	}
 
 	return r.update(ctx, namespacedName, updateFnc)
@@ -161,7 +217,21 @@ func (r *ClusterAssetReconciler) isStatusUnchanged(instance *assetstorev1beta1.C
 	return newStatus == nil ||
 		currentStatus.ObservedGeneration == newStatus.ObservedGeneration &&
 			currentStatus.Phase == newStatus.Phase &&
-			currentStatus.Reason == newStatus.Reason
+			currentStatus.Reason == newStatus.Reason &&
+			currentStatus.LastResyncRequestedAt == newStatus.LastResyncRequestedAt &&
+			currentStatus.RetryCount == newStatus.RetryCount &&
+			currentStatus.WebhooksFingerprint == newStatus.WebhooksFingerprint &&
+			currentStatus.ExpirationTime.Equal(newStatus.ExpirationTime) &&
+			equality.Semantic.DeepEqual(currentStatus.PendingNotification, newStatus.PendingNotification)
+}
+
+// requeueAfter shortens the relist interval when the next retry of a failed asset or a notification, or the expiration is due earlier
+func (r *ClusterAssetReconciler) requeueAfter(currentStatus assetstorev1beta1.CommonAssetStatus, newStatus *assetstorev1beta1.CommonAssetStatus) time.Duration {
+	if newStatus != nil {
+		currentStatus = *newStatus
+	}
+
+	return requeueBefore(r.relistInterval, currentStatus.NextRetryTime, currentStatus.ExpirationTime, nextNotificationTime(currentStatus))
 }
 
 func (r *ClusterAssetReconciler) update(ctx context.Context, namespacedName types.NamespacedName, updateFnc func(instance *assetstorev1beta1.ClusterAsset) error) error {
@@ -187,6 +257,19 @@ func (r *ClusterAssetReconciler) update(ctx context.Context, namespacedName type
 	return err
 }
 
+func (r *ClusterAssetReconciler) findStore(ctx context.Context, namespace, name string) (store.Store, error) {
+	bucketStatus, isReady, err := r.findClusterBucket(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if !isReady {
+		return r.store, nil
+	}
+
+	assetStore, _, err := resolveStore(ctx, r.store, r.storeClasses, bucketStatus.StoreClassName)
+	return assetStore, err
+}
+
 func (r *ClusterAssetReconciler) findClusterBucket(ctx context.Context, namespace, name string) (*assetstorev1beta1.CommonBucketStatus, bool, error) {
 	instance := &assetstorev1beta1.ClusterBucket{}
 
@@ -207,10 +290,52 @@ func (r *ClusterAssetReconciler) findClusterBucket(ctx context.Context, namespac
 }
 
 func (r *ClusterAssetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(&assetstorev1beta1.ClusterAsset{}, bucketRefNameField, indexClusterAssetBucketRefName); err != nil {
+		return errors.Wrapf(err, "while indexing ClusterAssets by %s", bucketRefNameField)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(&assetstorev1beta1.ClusterAsset{}, configMapSourceField, indexClusterAssetConfigMapSource); err != nil {
+		return errors.Wrapf(err, "while indexing ClusterAssets by %s", configMapSourceField)
+	}
+	if err := metrics.Registry.Register(&clusterAssetPhaseCollector{reader: mgr.GetClient()}); err != nil {
+		return errors.Wrap(err, "while registering ClusterAssets metrics")
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&assetstorev1beta1.ClusterAsset{}).
+		Watches(&source.Kind{Type: &assetstorev1beta1.ClusterBucket{}}, newBucketEventHandler(r.findAssetsForBucket)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(r.findAssetsForConfigMap)}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.maxConcurrentReconciles,
 		}).
 		Complete(r)
 }
+
+func (r *ClusterAssetReconciler) findAssetsForBucket(obj handler.MapObject) []reconcile.Request {
+	instances := &assetstorev1beta1.ClusterAssetList{}
+	if err := r.List(context.Background(), instances, client.MatchingFields{bucketRefNameField: obj.Meta.GetName()}); err != nil {
+		r.Log.Error(err, "Unable to list ClusterAssets for ClusterBucket", "name", obj.Meta.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, item := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+	}
+
+	return requests
+}
+
+func (r *ClusterAssetReconciler) findAssetsForConfigMap(obj handler.MapObject) []reconcile.Request {
+	instances := &assetstorev1beta1.ClusterAssetList{}
+	if err := r.List(context.Background(), instances, client.MatchingFields{configMapSourceField: configMapKey(obj)}); err != nil {
+		r.Log.Error(err, "Unable to list ClusterAssets for ConfigMap", "name", obj.Meta.GetName(), "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(instances.Items))
+	for _, item := range instances.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+	}
+
+	return requests
+}
@@ -62,6 +62,7 @@ var _ = Describe("ClusterAsset", func() {
 			validator:               mocks.Validator,
 			mutator:                 mocks.Mutator,
 			metadataExtractor:       mocks.Extractor,
+			notifier:                mocks.Notifier,
 			maxConcurrentReconciles: 1,
 		}
 	})
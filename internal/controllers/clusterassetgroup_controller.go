@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyma-project/rafter/internal/cloudevents"
+	"github.com/kyma-project/rafter/internal/expiry"
 	"github.com/kyma-project/rafter/internal/handler/assetgroup"
 	"github.com/kyma-project/rafter/internal/webhookconfig"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
@@ -26,6 +28,7 @@ type ClusterAssetGroupReconciler struct {
 	assetSvc         assetgroup.AssetService
 	bucketSvc        assetgroup.BucketService
 	webhookConfigSvc webhookconfig.AssetWebhookConfigService
+	events           cloudevents.Emitter
 }
 
 type ClusterAssetGroupConfig struct {
@@ -33,7 +36,7 @@ type ClusterAssetGroupConfig struct {
 	BucketRegion   string        `envconfig:"-"`
 }
 
-func NewClusterAssetGroup(config ClusterAssetGroupConfig, log logr.Logger, mgr ctrl.Manager, webhookConfigSvc webhookconfig.AssetWebhookConfigService) *ClusterAssetGroupReconciler {
+func NewClusterAssetGroup(config ClusterAssetGroupConfig, log logr.Logger, mgr ctrl.Manager, webhookConfigSvc webhookconfig.AssetWebhookConfigService, events cloudevents.Emitter) *ClusterAssetGroupReconciler {
 	assetService := newClusterAssetService(mgr.GetClient(), mgr.GetScheme())
 	bucketService := newClusterBucketService(mgr.GetClient(), mgr.GetScheme(), config.BucketRegion)
 
@@ -45,6 +48,7 @@ func NewClusterAssetGroup(config ClusterAssetGroupConfig, log logr.Logger, mgr c
 		assetSvc:         assetService,
 		bucketSvc:        bucketService,
 		webhookConfigSvc: webhookConfigSvc,
+		events:           events,
 	}
 }
 
@@ -72,6 +76,11 @@ func (r *ClusterAssetGroupReconciler) Reconcile(req ctrl.Request) (ctrl.Result,
 		return ctrl.Result{}, err
 	}
 
+	expirationTime := expiry.Time(instance.CreationTimestamp, instance.Spec.TTL, instance.Spec.ExpiresAt)
+	if instance.DeletionTimestamp.IsZero() && expiry.IsExpired(expirationTime, time.Now()) {
+		return ctrl.Result{}, deleteExpired(ctx, r.Client, r.recorder, instance, cmsv1alpha1.AssetGroupExpired.String(), cmsv1alpha1.AssetGroupExpired.Message(), expirationTime)
+	}
+
 	assetGroupLogger := r.Log.WithValues("kind", instance.GetObjectKind().GroupVersionKind().Kind, "name", instance.GetName())
 	commonHandler := assetgroup.New(assetGroupLogger, r.recorder, r.assetSvc, r.bucketSvc, r.webhookConfigSvc)
 	commonStatus, err := commonHandler.Handle(ctx, instance, instance.Spec.CommonAssetGroupSpec, instance.Status.CommonAssetGroupStatus)
@@ -87,7 +96,7 @@ func (r *ClusterAssetGroupReconciler) Reconcile(req ctrl.Request) (ctrl.Result,
 	}
 
 	return ctrl.Result{
-		RequeueAfter: r.relistInterval,
+		RequeueAfter: requeueBefore(r.relistInterval, expirationTime),
 	}, nil
 }
 
@@ -99,7 +108,12 @@ func (r *ClusterAssetGroupReconciler) updateStatus(ctx context.Context, instance
 	copy := instance.DeepCopy()
 	copy.Status.CommonAssetGroupStatus = *commonStatus
 
-	return r.Status().Update(ctx, copy)
+	if err := r.Status().Update(ctx, copy); err != nil {
+		return err
+	}
+	emitPhaseEvent(r.events, "ClusterAssetGroup", copy, string(instance.Status.Phase), string(commonStatus.Phase), copy.Status)
+
+	return nil
 }
 
 func (r *ClusterAssetGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/rafter/internal/handler/asset"
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// configMapSourceField indexes assets in configmap mode by the namespace/name of the source ConfigMap
+const configMapSourceField = "spec.source.configMap"
+
+func indexAssetConfigMapSource(obj runtime.Object) []string {
+	instance, ok := obj.(*assetstorev1beta1.Asset)
+	if !ok {
+		return nil
+	}
+
+	return configMapSource(instance.Spec.Source)
+}
+
+func indexClusterAssetConfigMapSource(obj runtime.Object) []string {
+	instance, ok := obj.(*assetstorev1beta1.ClusterAsset)
+	if !ok {
+		return nil
+	}
+
+	return configMapSource(instance.Spec.Source)
+}
+
+func configMapSource(source assetstorev1beta1.AssetSource) []string {
+	if source.Mode != assetstorev1beta1.AssetConfigMap || source.URL == "" {
+		return nil
+	}
+
+	return []string{source.URL}
+}
+
+func configMapKey(obj handler.MapObject) string {
+	return fmt.Sprintf("%s/%s", obj.Meta.GetNamespace(), obj.Meta.GetName())
+}
+
+func newConfigMapVersionFinder(reader client.Reader) asset.FindConfigMapVersion {
+	return func(ctx context.Context, namespace, name string) (string, error) {
+		instance := &corev1.ConfigMap{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, instance); err != nil {
+			if apiErrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", errors.Wrapf(err, "while getting ConfigMap %s in namespace %s", name, namespace)
+		}
+
+		return instance.ResourceVersion, nil
+	}
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"testing"
+
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+)
+
+func TestIndexAssetConfigMapSource(t *testing.T) {
+	t.Run("ConfigMapMode", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		asset := fixConfigMapSourceAsset(assetstorev1beta1.AssetConfigMap)
+
+		// When
+		values := indexAssetConfigMapSource(asset)
+
+		// Then
+		g.Expect(values).To(gomega.ConsistOf("test-ns/test-configmap"))
+	})
+
+	t.Run("OtherMode", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		asset := fixConfigMapSourceAsset(assetstorev1beta1.AssetSingle)
+
+		// When
+		values := indexAssetConfigMapSource(asset)
+
+		// Then
+		g.Expect(values).To(gomega.BeEmpty())
+	})
+}
+
+func fixConfigMapSourceAsset(mode assetstorev1beta1.AssetMode) *assetstorev1beta1.Asset {
+	return &assetstorev1beta1.Asset{
+		Spec: assetstorev1beta1.AssetSpec{
+			CommonAssetSpec: assetstorev1beta1.CommonAssetSpec{
+				Source: assetstorev1beta1.AssetSource{
+					Mode: mode,
+					URL:  "test-ns/test-configmap",
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/finalizer"
+	"github.com/kyma-project/rafter/internal/store/automock"
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestDeletionPolicy_BucketDeletedBeforeAsset(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		deletionPolicy assetstorev1beta1.BucketDeletionPolicy
+		expectStore    func(store *automock.Store)
+	}{
+		"Delete": {
+			deletionPolicy: assetstorev1beta1.BucketDeletionPolicyDelete,
+			expectStore: func(store *automock.Store) {
+				store.On("DeleteBucket", mock.Anything, "remote-bucket").Return(nil).Once()
+			},
+		},
+		"Retain": {
+			deletionPolicy: assetstorev1beta1.BucketDeletionPolicyRetain,
+			expectStore: func(store *automock.Store) {
+				store.On("MarkRetained", mock.Anything, "remote-bucket").Return(nil).Once()
+			},
+		},
+		"Orphan": {
+			deletionPolicy: assetstorev1beta1.BucketDeletionPolicyOrphan,
+			expectStore: func(store *automock.Store) {
+				store.On("DeleteObjects", mock.Anything, "remote-bucket", "").Return(nil).Once()
+				store.On("MarkRetained", mock.Anything, "remote-bucket").Return(nil).Once()
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			ctx := context.TODO()
+			scheme := runtime.NewScheme()
+			g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+			deletionTimestamp := v1.Now()
+
+			bucket := &assetstorev1beta1.Bucket{
+				ObjectMeta: v1.ObjectMeta{Name: "test-bucket", Namespace: "test-ns", Finalizers: []string{"test"}, DeletionTimestamp: &deletionTimestamp},
+				Spec: assetstorev1beta1.BucketSpec{
+					CommonBucketSpec: assetstorev1beta1.CommonBucketSpec{DeletionPolicy: testCase.deletionPolicy},
+				},
+				Status: assetstorev1beta1.BucketStatus{
+					CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{
+						Phase:          assetstorev1beta1.BucketReady,
+						RemoteName:     "remote-bucket",
+						DeletionPolicy: testCase.deletionPolicy,
+					},
+				},
+			}
+			asset := &assetstorev1beta1.Asset{
+				ObjectMeta: v1.ObjectMeta{Name: "test-asset", Namespace: "test-ns", Finalizers: []string{"test"}, DeletionTimestamp: &deletionTimestamp},
+				Spec: assetstorev1beta1.AssetSpec{
+					CommonAssetSpec: assetstorev1beta1.CommonAssetSpec{
+						BucketRef: assetstorev1beta1.AssetBucketRef{Name: bucket.Name},
+					},
+				},
+				Status: assetstorev1beta1.AssetStatus{
+					CommonAssetStatus: assetstorev1beta1.CommonAssetStatus{Phase: assetstorev1beta1.AssetReady},
+				},
+			}
+			k8sClient := fake.NewFakeClientWithScheme(scheme, bucket, asset)
+
+			store := new(automock.Store)
+			defer store.AssertExpectations(t)
+			testCase.expectStore(store)
+
+			bucketReconciler := &BucketReconciler{
+				Client:            k8sClient,
+				cacheSynchronizer: func(stop <-chan struct{}) bool { return true },
+				Log:               log.Log,
+				recorder:          record.NewFakeRecorder(100),
+				relistInterval:    time.Hour,
+				store:             store,
+				finalizer:         finalizer.New("test"),
+			}
+			assetReconciler := &AssetReconciler{
+				Client:            k8sClient,
+				cacheSynchronizer: func(stop <-chan struct{}) bool { return true },
+				Log:               log.Log,
+				recorder:          record.NewFakeRecorder(100),
+				relistInterval:    time.Hour,
+				store:             store,
+				finalizer:         finalizer.New("test"),
+			}
+
+			// When
+			_, err := bucketReconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: bucket.Namespace, Name: bucket.Name}})
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(k8sClient.Delete(ctx, bucket)).To(gomega.Succeed())
+
+			_, err = assetReconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: asset.Namespace, Name: asset.Name}})
+
+			// Then
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			deleted := &assetstorev1beta1.Asset{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: asset.Namespace, Name: asset.Name}, deleted)).To(gomega.Succeed())
+			g.Expect(deleted.Finalizers).To(gomega.BeEmpty())
+		})
+	}
+}
@@ -2,16 +2,21 @@ package controllers
 
 import (
 	"github.com/kyma-project/rafter/internal/assethook"
+	"github.com/kyma-project/rafter/internal/cloudevents"
 	"github.com/kyma-project/rafter/internal/loader"
 	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/storeclass"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 type Container struct {
-	Manager   ctrl.Manager
-	Store     store.Store
-	Loader    loader.Loader
-	Validator assethook.Validator
-	Mutator   assethook.Mutator
-	Extractor assethook.MetadataExtractor
+	Manager      ctrl.Manager
+	Store        store.Store
+	StoreClasses storeclass.Provider
+	Loader       loader.Loader
+	Validator    assethook.Validator
+	Mutator      assethook.Mutator
+	Extractor    assethook.MetadataExtractor
+	Notifier     assethook.Notifier
+	Events       cloudevents.Emitter
 }
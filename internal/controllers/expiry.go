@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deleteExpired announces the expiration with an event and deletes the instance
+func deleteExpired(ctx context.Context, c client.Client, recorder record.EventRecorder, instance runtime.Object, reason, message string, expirationTime *metav1.Time) error {
+	recorder.Eventf(instance, "Normal", reason, message, expirationTime.Format(time.RFC3339))
+	if err := c.Delete(ctx, instance); err != nil && !apiErrors.IsNotFound(err) {
+		return errors.Wrap(err, "while deleting expired instance")
+	}
+
+	return nil
+}
+
+// requeueBefore shortens the relist interval when any of the due times comes earlier
+func requeueBefore(relistInterval time.Duration, dueTimes ...*metav1.Time) time.Duration {
+	result := relistInterval
+	for _, dueTime := range dueTimes {
+		if dueTime == nil {
+			continue
+		}
+
+		after := time.Until(dueTime.Time)
+		if after > 0 && after < result {
+			result = after
+		}
+	}
+
+	return result
+}
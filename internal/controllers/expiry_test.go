@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDeleteExpired(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	instance := &assetstorev1beta1.Asset{
+		ObjectMeta: v1.ObjectMeta{Namespace: "test-ns", Name: "test-asset"},
+	}
+	c := fake.NewFakeClientWithScheme(scheme, instance)
+	recorder := record.NewFakeRecorder(1)
+	expirationTime := v1.NewTime(time.Now().Add(-time.Minute))
+
+	// When
+	err := deleteExpired(ctx, c, recorder, instance, assetstorev1beta1.AssetExpired.String(), assetstorev1beta1.AssetExpired.Message(), &expirationTime)
+
+	// Then
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(recorder.Events).To(gomega.HaveLen(1))
+	err = c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "test-asset"}, &assetstorev1beta1.Asset{})
+	g.Expect(apiErrors.IsNotFound(err)).To(gomega.BeTrue())
+}
+
+func TestRequeueBefore(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	relistInterval := time.Hour
+	past := v1.NewTime(time.Now().Add(-time.Minute))
+	soon := v1.NewTime(time.Now().Add(time.Minute))
+	later := v1.NewTime(time.Now().Add(2 * time.Hour))
+
+	// When & Then
+	g.Expect(requeueBefore(relistInterval)).To(gomega.Equal(relistInterval))
+	g.Expect(requeueBefore(relistInterval, nil, &past, &later)).To(gomega.Equal(relistInterval))
+	g.Expect(requeueBefore(relistInterval, &later, &soon)).To(gomega.BeNumerically("<=", time.Minute))
+}
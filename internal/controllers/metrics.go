@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	assetsDesc = prometheus.NewDesc(
+		"rafter_controller_manager_assets",
+		"Number of assets by namespace and phase",
+		[]string{"namespace", "phase"}, nil,
+	)
+	clusterAssetsDesc = prometheus.NewDesc(
+		"rafter_controller_manager_cluster_assets",
+		"Number of cluster assets by phase",
+		[]string{"phase"}, nil,
+	)
+)
+
+type phaseKey struct {
+	namespace string
+	phase     assetstorev1beta1.AssetPhase
+}
+
+// assetPhaseCollector counts Assets per namespace and phase on every scrape
+type assetPhaseCollector struct {
+	reader client.Reader
+}
+
+var _ prometheus.Collector = &assetPhaseCollector{}
+
+func (c *assetPhaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- assetsDesc
+}
+
+func (c *assetPhaseCollector) Collect(ch chan<- prometheus.Metric) {
+	instances := &assetstorev1beta1.AssetList{}
+	if err := c.reader.List(context.Background(), instances); err != nil {
+		ch <- prometheus.NewInvalidMetric(assetsDesc, errors.Wrap(err, "while listing Assets"))
+		return
+	}
+
+	counts := make(map[phaseKey]int)
+	for _, item := range instances.Items {
+		counts[phaseKey{namespace: item.Namespace, phase: item.Status.Phase}]++
+	}
+
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(assetsDesc, prometheus.GaugeValue, float64(count), key.namespace, string(key.phase))
+	}
+}
+
+// clusterAssetPhaseCollector counts ClusterAssets per phase on every scrape
+type clusterAssetPhaseCollector struct {
+	reader client.Reader
+}
+
+var _ prometheus.Collector = &clusterAssetPhaseCollector{}
+
+func (c *clusterAssetPhaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterAssetsDesc
+}
+
+func (c *clusterAssetPhaseCollector) Collect(ch chan<- prometheus.Metric) {
+	instances := &assetstorev1beta1.ClusterAssetList{}
+	if err := c.reader.List(context.Background(), instances); err != nil {
+		ch <- prometheus.NewInvalidMetric(clusterAssetsDesc, errors.Wrap(err, "while listing ClusterAssets"))
+		return
+	}
+
+	counts := make(map[assetstorev1beta1.AssetPhase]int)
+	for _, item := range instances.Items {
+		counts[item.Status.Phase]++
+	}
+
+	for phase, count := range counts {
+		ch <- prometheus.MustNewConstMetric(clusterAssetsDesc, prometheus.GaugeValue, float64(count), string(phase))
+	}
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAssetPhaseCollector(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	reader := fake.NewFakeClientWithScheme(scheme,
+		fixAssetPhase("ns-a", "asset-1", assetstorev1beta1.AssetReady),
+		fixAssetPhase("ns-a", "asset-2", assetstorev1beta1.AssetReady),
+		fixAssetPhase("ns-b", "asset-3", assetstorev1beta1.AssetFailed),
+	)
+	collector := &assetPhaseCollector{reader: reader}
+	expected := `
+# HELP rafter_controller_manager_assets Number of assets by namespace and phase
+# TYPE rafter_controller_manager_assets gauge
+rafter_controller_manager_assets{namespace="ns-a",phase="Ready"} 2
+rafter_controller_manager_assets{namespace="ns-b",phase="Failed"} 1
+`
+
+	// When
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+
+	// Then
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
+
+func TestClusterAssetPhaseCollector(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	reader := fake.NewFakeClientWithScheme(scheme,
+		&assetstorev1beta1.ClusterAsset{
+			ObjectMeta: v1.ObjectMeta{Name: "asset-1"},
+			Status: assetstorev1beta1.ClusterAssetStatus{
+				CommonAssetStatus: assetstorev1beta1.CommonAssetStatus{Phase: assetstorev1beta1.AssetPending},
+			},
+		},
+	)
+	collector := &clusterAssetPhaseCollector{reader: reader}
+	expected := `
+# HELP rafter_controller_manager_cluster_assets Number of cluster assets by phase
+# TYPE rafter_controller_manager_cluster_assets gauge
+rafter_controller_manager_cluster_assets{phase="Pending"} 1
+`
+
+	// When
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+
+	// Then
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
+
+func fixAssetPhase(namespace, name string, phase assetstorev1beta1.AssetPhase) *assetstorev1beta1.Asset {
+	return &assetstorev1beta1.Asset{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Status: assetstorev1beta1.AssetStatus{
+			CommonAssetStatus: assetstorev1beta1.CommonAssetStatus{
+				Phase: phase,
+			},
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// isDeletionNotificationPending returns true when the finalizer must be kept until notification webhooks accept the deletion notification
+func isDeletionNotificationPending(status *assetstorev1beta1.CommonAssetStatus) bool {
+	return status != nil &&
+		status.PendingNotification != nil &&
+		status.PendingNotification.Event == assetstorev1beta1.AssetNotificationDeleted
+}
+
+// nextNotificationTime returns the time of the next attempt to send the pending notification
+func nextNotificationTime(status assetstorev1beta1.CommonAssetStatus) *metav1.Time {
+	if status.PendingNotification == nil {
+		return nil
+	}
+
+	return &status.PendingNotification.NextAttemptTime
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/kyma-project/rafter/internal/handler/bucket"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRemoteNameUsersFinder(reader client.Reader) bucket.FindRemoteNameUsers {
+	return func(ctx context.Context, remoteName string) ([]types.NamespacedName, error) {
+		var users []types.NamespacedName
+
+		buckets := &v1beta1.BucketList{}
+		if err := reader.List(ctx, buckets); err != nil {
+			return nil, errors.Wrap(err, "while listing Buckets")
+		}
+		for _, item := range buckets.Items {
+			if item.Status.RemoteName == remoteName {
+				users = append(users, types.NamespacedName{Namespace: item.Namespace, Name: item.Name})
+			}
+		}
+
+		clusterBuckets := &v1beta1.ClusterBucketList{}
+		if err := reader.List(ctx, clusterBuckets); err != nil {
+			return nil, errors.Wrap(err, "while listing ClusterBuckets")
+		}
+		for _, item := range clusterBuckets.Items {
+			if item.Status.RemoteName == remoteName {
+				users = append(users, types.NamespacedName{Name: item.Name})
+			}
+		}
+
+		return users, nil
+	}
+}
+
+func compileAdoptableRemoteNames(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	adoptableRemoteNames, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while compiling adoptable remote names expression %s", expr)
+	}
+
+	return adoptableRemoteNames, nil
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/kyma-project/rafter/internal/handler/asset"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newSecretKeyFinder(reader client.Reader) asset.FindSecretKey {
+	return func(ctx context.Context, namespace, name, key string) ([]byte, error) {
+		instance := &corev1.Secret{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, instance); err != nil {
+			return nil, errors.Wrapf(err, "while getting Secret %s in namespace %s", name, namespace)
+		}
+
+		value, ok := instance.Data[key]
+		if !ok {
+			return nil, errors.Errorf("key %s not found in Secret %s in namespace %s", key, name, namespace)
+		}
+
+		return value, nil
+	}
+}
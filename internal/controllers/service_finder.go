@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/kyma-project/rafter/internal/handler/asset"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newServiceVersionFinder(reader client.Reader) asset.FindServiceVersion {
+	return func(ctx context.Context, namespace, name string) (string, error) {
+		instance := &corev1.Service{}
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, instance); err != nil {
+			if apiErrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", errors.Wrapf(err, "while getting Service %s in namespace %s", name, namespace)
+		}
+
+		return instance.ResourceVersion, nil
+	}
+}
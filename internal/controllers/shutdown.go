@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// shutdown cancels reconcile contexts when the manager stops, aborting in-flight webhook calls
+type shutdown struct {
+	stop <-chan struct{}
+}
+
+var _ inject.Stoppable = &shutdown{}
+
+// InjectStopChannel is called by the manager when the reconciler is registered
+func (s *shutdown) InjectStopChannel(stop <-chan struct{}) error {
+	s.stop = stop
+	return nil
+}
+
+// context returns a context canceled when either the returned function is called or the manager stops
+func (s *shutdown) context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if s.stop == nil {
+		return ctx, cancel
+	}
+
+	go func() {
+		select {
+		case <-s.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
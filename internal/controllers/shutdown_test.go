@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestShutdown_Context(t *testing.T) {
+	t.Run("CanceledOnStop", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		stop := make(chan struct{})
+		s := &shutdown{}
+		g.Expect(s.InjectStopChannel(stop)).To(gomega.Succeed())
+
+		// When
+		ctx, cancel := s.context()
+		defer cancel()
+		close(stop)
+
+		// Then
+		g.Eventually(ctx.Done()).Should(gomega.BeClosed())
+	})
+
+	t.Run("CanceledOnCancel", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		s := &shutdown{}
+		g.Expect(s.InjectStopChannel(make(chan struct{}))).To(gomega.Succeed())
+
+		// When
+		ctx, cancel := s.context()
+		cancel()
+
+		// Then
+		g.Expect(ctx.Done()).To(gomega.BeClosed())
+	})
+
+	t.Run("WithoutStopChannel", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		s := &shutdown{}
+
+		// When
+		ctx, cancel := s.context()
+		defer cancel()
+
+		// Then
+		g.Expect(ctx.Err()).ToNot(gomega.HaveOccurred())
+	})
+}
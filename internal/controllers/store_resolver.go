@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/storeclass"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func resolveStore(ctx context.Context, defaultStore store.Store, storeClasses storeclass.Provider, storeClassName string) (store.Store, *v1beta1.StoreClassSpec, error) {
+	if storeClassName == "" {
+		return defaultStore, nil, nil
+	}
+
+	return storeClasses.Get(ctx, storeClassName)
+}
+
+func applyStoreClass(spec v1beta1.CommonBucketSpec, externalEndpoint string, storeClass *v1beta1.StoreClassSpec) (v1beta1.CommonBucketSpec, string) {
+	if storeClass == nil {
+		return spec, externalEndpoint
+	}
+
+	if spec.Region == "" {
+		spec.Region = storeClass.Region
+	}
+
+	return spec, storeclass.ExternalEndpoint(storeClass)
+}
+
+// isStoreMissing checks if the store can't be resolved because its StoreClass or credentials Secret doesn't exist
+func isStoreMissing(err error) bool {
+	return apiErrors.IsNotFound(errors.Cause(err))
+}
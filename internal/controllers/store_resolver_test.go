@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/finalizer"
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/store/automock"
+	"github.com/kyma-project/rafter/internal/storeclass"
+	assetstorev1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestIsStoreMissing(t *testing.T) {
+	notFound := apiErrors.NewNotFound(schema.GroupResource{Group: "rafter.kyma-project.io", Resource: "storeclasses"}, "test")
+
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(isStoreMissing(errors.Wrap(notFound, "while getting StoreClass test"))).To(gomega.BeTrue())
+	g.Expect(isStoreMissing(errors.New("test-error"))).To(gomega.BeFalse())
+}
+
+func TestBucketReconciler_Reconcile_MissingStoreClass(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		deleted          bool
+		expectErr        bool
+		expectFinalizers []string
+	}{
+		"Deleted":    {deleted: true},
+		"NotDeleted": {expectErr: true, expectFinalizers: []string{"test"}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+			ctx := context.TODO()
+			scheme := runtime.NewScheme()
+			g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+
+			bucket := &assetstorev1beta1.Bucket{
+				ObjectMeta: v1.ObjectMeta{Name: "test-bucket", Namespace: "test-ns", Finalizers: []string{"test"}},
+				Spec: assetstorev1beta1.BucketSpec{
+					CommonBucketSpec: assetstorev1beta1.CommonBucketSpec{StoreClassName: "missing"},
+				},
+				Status: assetstorev1beta1.BucketStatus{
+					CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{
+						Phase:          assetstorev1beta1.BucketReady,
+						RemoteName:     "remote-bucket",
+						StoreClassName: "missing",
+					},
+				},
+			}
+			if testCase.deleted {
+				deletionTimestamp := v1.Now()
+				bucket.DeletionTimestamp = &deletionTimestamp
+			}
+			k8sClient := fake.NewFakeClientWithScheme(scheme, bucket)
+
+			defaultStore := new(automock.Store)
+			defer defaultStore.AssertExpectations(t)
+
+			reconciler := &BucketReconciler{
+				Client:            k8sClient,
+				cacheSynchronizer: func(stop <-chan struct{}) bool { return true },
+				Log:               log.Log,
+				recorder:          record.NewFakeRecorder(100),
+				relistInterval:    time.Hour,
+				store:             defaultStore,
+				storeClasses:      storeclass.New(k8sClient, nil, func(client store.MinioClient) store.Store { return nil }),
+				finalizer:         finalizer.New("test"),
+			}
+
+			// When
+			_, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: bucket.Namespace, Name: bucket.Name}})
+
+			// Then
+			if testCase.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(gomega.HaveOccurred())
+			}
+			result := &assetstorev1beta1.Bucket{}
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: bucket.Namespace, Name: bucket.Name}, result)).To(gomega.Succeed())
+			g.Expect(result.Finalizers).To(gomega.Equal(testCase.expectFinalizers))
+		})
+	}
+}
+
+func TestAssetReconciler_Reconcile_MissingStoreClass(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	g.Expect(assetstorev1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	deletionTimestamp := v1.Now()
+
+	bucket := &assetstorev1beta1.Bucket{
+		ObjectMeta: v1.ObjectMeta{Name: "test-bucket", Namespace: "test-ns"},
+		Status: assetstorev1beta1.BucketStatus{
+			CommonBucketStatus: assetstorev1beta1.CommonBucketStatus{
+				Phase:          assetstorev1beta1.BucketReady,
+				RemoteName:     "remote-bucket",
+				StoreClassName: "missing",
+			},
+		},
+	}
+	asset := &assetstorev1beta1.Asset{
+		ObjectMeta: v1.ObjectMeta{Name: "test-asset", Namespace: "test-ns", Finalizers: []string{"test"}, DeletionTimestamp: &deletionTimestamp},
+		Spec: assetstorev1beta1.AssetSpec{
+			CommonAssetSpec: assetstorev1beta1.CommonAssetSpec{
+				BucketRef: assetstorev1beta1.AssetBucketRef{Name: bucket.Name},
+			},
+		},
+	}
+	k8sClient := fake.NewFakeClientWithScheme(scheme, bucket, asset)
+
+	defaultStore := new(automock.Store)
+	defer defaultStore.AssertExpectations(t)
+
+	reconciler := &AssetReconciler{
+		Client:            k8sClient,
+		cacheSynchronizer: func(stop <-chan struct{}) bool { return true },
+		Log:               log.Log,
+		recorder:          record.NewFakeRecorder(100),
+		relistInterval:    time.Hour,
+		store:             defaultStore,
+		storeClasses:      storeclass.New(k8sClient, nil, func(client store.MinioClient) store.Store { return nil }),
+		finalizer:         finalizer.New("test"),
+	}
+
+	// When
+	_, err := reconciler.Reconcile(ctrl.Request{NamespacedName: types.NamespacedName{Namespace: asset.Namespace, Name: asset.Name}})
+
+	// Then
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	result := &assetstorev1beta1.Asset{}
+	g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: asset.Namespace, Name: asset.Name}, result)).To(gomega.Succeed())
+	g.Expect(result.Finalizers).To(gomega.BeEmpty())
+}
@@ -107,6 +107,7 @@ func initWebhookConfigService(webhookCfg webhookconfig.Config, config *rest.Conf
 type MockContainer struct {
 	Store     *store.Store
 	Extractor *assethook.MetadataExtractor
+	Notifier  *assethook.Notifier
 	Mutator   *assethook.Mutator
 	Validator *assethook.Validator
 	Loader    *loader.Loader
@@ -116,6 +117,7 @@ func NewMockContainer() *MockContainer {
 	return &MockContainer{
 		Store:     new(store.Store),
 		Extractor: new(assethook.MetadataExtractor),
+		Notifier:  new(assethook.Notifier),
 		Mutator:   new(assethook.Mutator),
 		Validator: new(assethook.Validator),
 		Loader:    new(loader.Loader),
@@ -125,6 +127,7 @@ func NewMockContainer() *MockContainer {
 func (c *MockContainer) AssertExpetactions(t GinkgoTInterface) {
 	c.Store.AssertExpectations(t)
 	c.Extractor.AssertExpectations(t)
+	c.Notifier.AssertExpectations(t)
 	c.Mutator.AssertExpectations(t)
 	c.Validator.AssertExpectations(t)
 	c.Loader.AssertExpectations(t)
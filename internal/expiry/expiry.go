@@ -0,0 +1,28 @@
+package expiry
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Time returns the time when a resource created at creationTimestamp expires, or nil if it never expires.
+// The earlier time wins when both ttl and expiresAt are set.
+func Time(creationTimestamp v1.Time, ttl *v1.Duration, expiresAt *v1.Time) *v1.Time {
+	var result *v1.Time
+	if ttl != nil {
+		expirationTime := v1.NewTime(creationTimestamp.Add(ttl.Duration)).Rfc3339Copy()
+		result = &expirationTime
+	}
+	if expiresAt != nil && (result == nil || expiresAt.Before(result)) {
+		expirationTime := expiresAt.Rfc3339Copy()
+		result = &expirationTime
+	}
+
+	return result
+}
+
+// IsExpired checks if the expiration time has passed
+func IsExpired(expirationTime *v1.Time, now time.Time) bool {
+	return expirationTime != nil && !now.Before(expirationTime.Time)
+}
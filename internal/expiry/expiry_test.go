@@ -0,0 +1,72 @@
+package expiry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/expiry"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTime(t *testing.T) {
+	creationTimestamp := v1.NewTime(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+	ttl := &v1.Duration{Duration: time.Hour}
+	earlier := v1.NewTime(creationTimestamp.Add(30 * time.Minute))
+	later := v1.NewTime(creationTimestamp.Add(2 * time.Hour))
+
+	for testName, testCase := range map[string]struct {
+		ttl       *v1.Duration
+		expiresAt *v1.Time
+		expected  *v1.Time
+	}{
+		"NoExpiry": {},
+		"TTL": {
+			ttl:      ttl,
+			expected: &v1.Time{Time: creationTimestamp.Add(time.Hour)},
+		},
+		"ExpiresAt": {
+			expiresAt: &later,
+			expected:  &later,
+		},
+		"EarlierExpiresAt": {
+			ttl:       ttl,
+			expiresAt: &earlier,
+			expected:  &earlier,
+		},
+		"LaterExpiresAt": {
+			ttl:       ttl,
+			expiresAt: &later,
+			expected:  &v1.Time{Time: creationTimestamp.Add(time.Hour)},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			result := expiry.Time(creationTimestamp, testCase.ttl, testCase.expiresAt)
+
+			// Then
+			if testCase.expected == nil {
+				g.Expect(result).To(gomega.BeNil())
+				return
+			}
+			g.Expect(result).NotTo(gomega.BeNil())
+			g.Expect(result.Equal(testCase.expected)).To(gomega.BeTrue())
+		})
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	// Given
+	g := gomega.NewGomegaWithT(t)
+	now := time.Now()
+	past := v1.NewTime(now.Add(-time.Minute))
+	future := v1.NewTime(now.Add(time.Minute))
+
+	// When & Then
+	g.Expect(expiry.IsExpired(nil, now)).To(gomega.BeFalse())
+	g.Expect(expiry.IsExpired(&past, now)).To(gomega.BeTrue())
+	g.Expect(expiry.IsExpired(&future, now)).To(gomega.BeFalse())
+}
@@ -0,0 +1,346 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyma-project/rafter/internal/bucket"
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Config of the garbage collector. Remote buckets created by the upload service are ignored, IgnoredBuckets
+// replaces the expression built from the upload service bucket prefixes if set.
+type Config struct {
+	Enabled        bool          `envconfig:"default=false"`
+	DryRun         bool          `envconfig:"default=true"`
+	Interval       time.Duration `envconfig:"default=1h"`
+	GracePeriod    time.Duration `envconfig:"default=24h"`
+	IgnoredBuckets string        `envconfig:"optional"`
+	Upload         bucket.Config
+}
+
+// Leftover is a remote bucket or an asset prefix that no resource references
+type Leftover struct {
+	Bucket       string
+	Prefix       string
+	LastModified time.Time
+	Deleted      bool
+}
+
+// Report describes leftovers found during a single collection
+type Report struct {
+	DryRun  bool
+	Objects []Leftover
+	Buckets []Leftover
+}
+
+// Collector periodically removes asset prefixes and remote buckets that are not referenced by any resource.
+// Only the default store is collected, stores of StoreClasses are left untouched. Remote buckets retained by
+// their deletion policy are never removed, neither is any content inside them.
+type Collector struct {
+	cfg            Config
+	log            logr.Logger
+	reader         client.Reader
+	store          store.Store
+	ignoredBuckets *regexp.Regexp
+	now            func() time.Time
+}
+
+var _ manager.Runnable = &Collector{}
+
+func New(cfg Config, log logr.Logger, reader client.Reader, store store.Store) (*Collector, error) {
+	var ignoredBuckets *regexp.Regexp
+	if expr := ignoredBucketsExpression(cfg); expr != "" {
+		var err error
+		ignoredBuckets, err = regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while compiling ignored buckets expression %s", expr)
+		}
+	}
+
+	return &Collector{
+		cfg:            cfg,
+		log:            log,
+		reader:         reader,
+		store:          store,
+		ignoredBuckets: ignoredBuckets,
+		now:            time.Now,
+	}, nil
+}
+
+// ignoredBucketsExpression matches the names of system buckets that the upload service generates from its prefixes
+func ignoredBucketsExpression(cfg Config) string {
+	if cfg.IgnoredBuckets != "" {
+		return cfg.IgnoredBuckets
+	}
+
+	var prefixes []string
+	for _, prefix := range []string{cfg.Upload.PrivatePrefix, cfg.Upload.PublicPrefix} {
+		if prefix != "" {
+			prefixes = append(prefixes, regexp.QuoteMeta(prefix))
+		}
+	}
+	if len(prefixes) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("^(%s)-", strings.Join(prefixes, "|"))
+}
+
+// Start runs the collection every interval until the stop channel is closed
+func (c *Collector) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	wait.Until(func() {
+		report, err := c.Collect(ctx)
+		if err != nil {
+			c.log.Error(err, "Garbage collection failed")
+		}
+		if report != nil {
+			c.logReport(report)
+		}
+	}, c.cfg.Interval, stop)
+
+	return nil
+}
+
+// Collect finds leftovers older than the grace period and deletes them unless dry-run is enabled
+func (c *Collector) Collect(ctx context.Context) (*Report, error) {
+	refs, err := c.findReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{DryRun: c.cfg.DryRun}
+	deadline := c.now().Add(-c.cfg.GracePeriod)
+
+	if err := c.collectObjects(ctx, refs, deadline, report); err != nil {
+		return report, err
+	}
+	if err := c.collectBuckets(ctx, refs, deadline, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+func (c *Collector) collectObjects(ctx context.Context, refs *references, deadline time.Time, report *Report) error {
+	for _, bucketName := range refs.defaultStoreBuckets() {
+		prefixes, err := c.store.ListPrefixes(ctx, bucketName)
+		if err != nil {
+			return errors.Wrapf(err, "while listing prefixes in bucket %s", bucketName)
+		}
+
+		for _, prefix := range sortedKeys(prefixes) {
+			lastModified := prefixes[prefix]
+			if refs.isAssetReferenced(bucketName, prefix) || lastModified.After(deadline) {
+				continue
+			}
+
+			leftover := Leftover{Bucket: bucketName, Prefix: prefix, LastModified: lastModified}
+			if !c.cfg.DryRun {
+				if err := c.store.DeleteObjects(ctx, bucketName, fmt.Sprintf("%s/", prefix)); err != nil {
+					return errors.Wrapf(err, "while deleting prefix %s in bucket %s", prefix, bucketName)
+				}
+				leftover.Deleted = true
+			}
+			report.Objects = append(report.Objects, leftover)
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) collectBuckets(ctx context.Context, refs *references, deadline time.Time, report *Report) error {
+	buckets, err := c.store.ListBuckets()
+	if err != nil {
+		return err
+	}
+
+	for _, bucketName := range sortedKeys(buckets) {
+		created := buckets[bucketName]
+		if refs.isBucketReferenced(bucketName) || c.isBucketIgnored(bucketName) || created.After(deadline) {
+			continue
+		}
+
+		retained, err := c.store.IsRetained(bucketName)
+		if err != nil {
+			return err
+		}
+		if retained {
+			continue
+		}
+
+		leftover := Leftover{Bucket: bucketName, LastModified: created}
+		if !c.cfg.DryRun {
+			if err := c.store.DeleteBucket(ctx, bucketName); err != nil {
+				return errors.Wrapf(err, "while deleting bucket %s", bucketName)
+			}
+			leftover.Deleted = true
+		}
+		report.Buckets = append(report.Buckets, leftover)
+	}
+
+	return nil
+}
+
+func (c *Collector) isBucketIgnored(name string) bool {
+	return c.ignoredBuckets != nil && c.ignoredBuckets.MatchString(name)
+}
+
+func (c *Collector) logReport(report *Report) {
+	for _, leftover := range report.Objects {
+		c.log.Info("Found unreferenced asset content", "bucket", leftover.Bucket, "prefix", leftover.Prefix, "lastModified", leftover.LastModified, "deleted", leftover.Deleted)
+	}
+	for _, leftover := range report.Buckets {
+		c.log.Info("Found unreferenced bucket", "bucket", leftover.Bucket, "created", leftover.LastModified, "deleted", leftover.Deleted)
+	}
+	c.log.Info("Garbage collection finished", "dryRun", report.DryRun, "objects", len(report.Objects), "buckets", len(report.Buckets))
+}
+
+type bucketKey struct {
+	namespace, name string
+}
+
+type bucketRef struct {
+	remoteName   string
+	defaultStore bool
+	retained     bool
+}
+
+type references struct {
+	buckets       map[bucketKey]bucketRef
+	remoteBuckets map[string]struct{}
+	assets        map[string]map[string]struct{}
+}
+
+func (c *Collector) findReferences(ctx context.Context) (*references, error) {
+	refs := &references{
+		buckets:       make(map[bucketKey]bucketRef),
+		remoteBuckets: make(map[string]struct{}),
+		assets:        make(map[string]map[string]struct{}),
+	}
+
+	buckets := &v1beta1.BucketList{}
+	if err := c.reader.List(ctx, buckets); err != nil {
+		return nil, errors.Wrap(err, "while listing Buckets")
+	}
+	for _, bucket := range buckets.Items {
+		refs.addBucket(bucketKey{namespace: bucket.Namespace, name: bucket.Name}, bucket.Spec.CommonBucketSpec, bucket.Status.CommonBucketStatus)
+	}
+
+	clusterBuckets := &v1beta1.ClusterBucketList{}
+	if err := c.reader.List(ctx, clusterBuckets); err != nil {
+		return nil, errors.Wrap(err, "while listing ClusterBuckets")
+	}
+	for _, bucket := range clusterBuckets.Items {
+		refs.addBucket(bucketKey{name: bucket.Name}, bucket.Spec.CommonBucketSpec, bucket.Status.CommonBucketStatus)
+	}
+
+	assets := &v1beta1.AssetList{}
+	if err := c.reader.List(ctx, assets); err != nil {
+		return nil, errors.Wrap(err, "while listing Assets")
+	}
+	for _, asset := range assets.Items {
+		if asset.Spec.BucketRef.Kind == v1beta1.AssetBucketRefKindClusterBucket {
+			refs.addAsset(bucketKey{name: asset.Spec.BucketRef.Name}, fmt.Sprintf("%s/%s/%s", store.NamespacedObjectsPrefix, asset.Namespace, asset.Name))
+			continue
+		}
+		refs.addAsset(bucketKey{namespace: asset.Namespace, name: asset.Spec.BucketRef.Name}, asset.Name)
+	}
+
+	clusterAssets := &v1beta1.ClusterAssetList{}
+	if err := c.reader.List(ctx, clusterAssets); err != nil {
+		return nil, errors.Wrap(err, "while listing ClusterAssets")
+	}
+	for _, asset := range clusterAssets.Items {
+		refs.addAsset(bucketKey{name: asset.Spec.BucketRef.Name}, asset.Name)
+	}
+
+	return refs, nil
+}
+
+func (r *references) addBucket(key bucketKey, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) {
+	if spec.RemoteName != "" {
+		r.remoteBuckets[spec.RemoteName] = struct{}{}
+	}
+	if status.RemoteName == "" {
+		return
+	}
+
+	r.remoteBuckets[status.RemoteName] = struct{}{}
+	r.buckets[key] = bucketRef{
+		remoteName:   status.RemoteName,
+		defaultStore: status.StoreClassName == "" && spec.StoreClassName == "",
+		retained:     status.DeletionPolicy == v1beta1.BucketDeletionPolicyRetain || spec.DeletionPolicy == v1beta1.BucketDeletionPolicyRetain,
+	}
+}
+
+func (r *references) addAsset(key bucketKey, name string) {
+	bucket, ok := r.buckets[key]
+	if !ok {
+		return
+	}
+
+	if r.assets[bucket.remoteName] == nil {
+		r.assets[bucket.remoteName] = make(map[string]struct{})
+	}
+	r.assets[bucket.remoteName][name] = struct{}{}
+}
+
+// defaultStoreBuckets returns the remote buckets of the default store whose content may be collected
+func (r *references) defaultStoreBuckets() []string {
+	seen := make(map[string]struct{})
+	for _, bucket := range r.buckets {
+		if bucket.retained {
+			seen[bucket.remoteName] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(r.buckets))
+	for _, bucket := range r.buckets {
+		if _, ok := seen[bucket.remoteName]; ok || !bucket.defaultStore {
+			continue
+		}
+		seen[bucket.remoteName] = struct{}{}
+		names = append(names, bucket.remoteName)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (r *references) isBucketReferenced(name string) bool {
+	_, ok := r.remoteBuckets[name]
+	return ok
+}
+
+func (r *references) isAssetReferenced(bucketName, name string) bool {
+	_, ok := r.assets[bucketName][name]
+	return ok
+}
+
+func sortedKeys(m map[string]time.Time) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
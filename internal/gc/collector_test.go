@@ -0,0 +1,349 @@
+package gc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/bucket"
+	"github.com/kyma-project/rafter/internal/gc"
+	"github.com/kyma-project/rafter/internal/store/automock"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	now = time.Now()
+	old = now.Add(-48 * time.Hour)
+)
+
+func TestCollector_Collect(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g, fixBucket("test-ns", "test-bucket", "remote-bucket"), fixAsset("test-ns", "test-asset", "test-bucket"))
+
+		store := new(automock.Store)
+		store.On("ListPrefixes", ctx, "remote-bucket").Return(map[string]time.Time{"test-asset": old, "orphan": old}, nil).Once()
+		store.On("ListBuckets").Return(map[string]time.Time{"remote-bucket": old, "orphan-bucket": old}, nil).Once()
+		store.On("IsRetained", "orphan-bucket").Return(false, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{DryRun: true, GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.DryRun).To(gomega.BeTrue())
+		g.Expect(report.Objects).To(gomega.ConsistOf(gc.Leftover{Bucket: "remote-bucket", Prefix: "orphan", LastModified: old}))
+		g.Expect(report.Buckets).To(gomega.ConsistOf(gc.Leftover{Bucket: "orphan-bucket", LastModified: old}))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g, fixClusterBucket("test-bucket", "remote-bucket"), fixClusterAsset("test-asset", "test-bucket"))
+
+		store := new(automock.Store)
+		store.On("ListPrefixes", ctx, "remote-bucket").Return(map[string]time.Time{"test-asset": old, "orphan": old}, nil).Once()
+		store.On("DeleteObjects", ctx, "remote-bucket", "orphan/").Return(nil).Once()
+		store.On("ListBuckets").Return(map[string]time.Time{"remote-bucket": old, "orphan-bucket": old}, nil).Once()
+		store.On("IsRetained", "orphan-bucket").Return(false, nil).Once()
+		store.On("DeleteBucket", ctx, "orphan-bucket").Return(nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Objects).To(gomega.ConsistOf(gc.Leftover{Bucket: "remote-bucket", Prefix: "orphan", LastModified: old, Deleted: true}))
+		g.Expect(report.Buckets).To(gomega.ConsistOf(gc.Leftover{Bucket: "orphan-bucket", LastModified: old, Deleted: true}))
+	})
+
+	t.Run("ClusterBucketAssets", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		asset := fixAsset("test-ns", "test-asset", "test-bucket")
+		asset.Spec.BucketRef.Kind = v1beta1.AssetBucketRefKindClusterBucket
+		reader := fakeClient(g, fixClusterBucket("test-bucket", "remote-bucket"), asset)
+
+		store := new(automock.Store)
+		store.On("ListPrefixes", ctx, "remote-bucket").Return(map[string]time.Time{"_namespaces/test-ns/test-asset": old, "_namespaces/test-ns/orphan": old}, nil).Once()
+		store.On("ListBuckets").Return(map[string]time.Time{"remote-bucket": old}, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{DryRun: true, GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Objects).To(gomega.ConsistOf(gc.Leftover{Bucket: "remote-bucket", Prefix: "_namespaces/test-ns/orphan", LastModified: old}))
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("RetainedBucket", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g)
+
+		store := new(automock.Store)
+		store.On("ListBuckets").Return(map[string]time.Time{"retained-bucket": old}, nil).Once()
+		store.On("IsRetained", "retained-bucket").Return(true, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("RetainedBucketObjects", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucket := fixBucket("test-ns", "test-bucket", "remote-bucket")
+		bucket.Status.DeletionPolicy = v1beta1.BucketDeletionPolicyRetain
+		reader := fakeClient(g, bucket, fixAsset("test-ns", "test-asset", "test-bucket"))
+
+		store := new(automock.Store)
+		store.On("ListBuckets").Return(map[string]time.Time{"remote-bucket": old}, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Objects).To(gomega.BeEmpty())
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g, fixBucket("test-ns", "test-bucket", "remote-bucket"))
+
+		store := new(automock.Store)
+		store.On("ListPrefixes", ctx, "remote-bucket").Return(map[string]time.Time{"recent": now}, nil).Once()
+		store.On("ListBuckets").Return(map[string]time.Time{"remote-bucket": old, "recent-bucket": now}, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Objects).To(gomega.BeEmpty())
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("IgnoredBuckets", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g)
+
+		store := new(automock.Store)
+		store.On("ListBuckets").Return(map[string]time.Time{"system-public-1a2b": old}, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour, IgnoredBuckets: "^system-(private|public)-"}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("UploadBuckets", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g)
+
+		store := new(automock.Store)
+		store.On("ListBuckets").Return(map[string]time.Time{"private-1a2b": old, "public-1a2b": old}, nil).Once()
+		defer store.AssertExpectations(t)
+
+		cfg := gc.Config{GracePeriod: 24 * time.Hour, Upload: bucket.Config{PrivatePrefix: "private", PublicPrefix: "public"}}
+		collector := newCollector(g, cfg, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("StoreClassBucket", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucket := fixBucket("test-ns", "test-bucket", "remote-bucket")
+		bucket.Status.StoreClassName = "external"
+		reader := fakeClient(g, bucket)
+
+		store := new(automock.Store)
+		store.On("ListBuckets").Return(map[string]time.Time{"remote-bucket": old}, nil).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		report, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(report.Buckets).To(gomega.BeEmpty())
+	})
+
+	t.Run("ListPrefixesError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g, fixBucket("test-ns", "test-bucket", "remote-bucket"))
+
+		store := new(automock.Store)
+		store.On("ListPrefixes", ctx, "remote-bucket").Return(nil, errors.New("test-err")).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		_, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("DeleteBucketError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g)
+
+		store := new(automock.Store)
+		store.On("ListBuckets").Return(map[string]time.Time{"orphan-bucket": old}, nil).Once()
+		store.On("IsRetained", "orphan-bucket").Return(false, nil).Once()
+		store.On("DeleteBucket", ctx, "orphan-bucket").Return(errors.New("test-err")).Once()
+		defer store.AssertExpectations(t)
+
+		collector := newCollector(g, gc.Config{GracePeriod: 24 * time.Hour}, reader, store)
+
+		// When
+		_, err := collector.Collect(ctx)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("InvalidIgnoredBuckets", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		// When
+		_, err := gc.New(gc.Config{IgnoredBuckets: "("}, logf.Log, fakeClient(g), new(automock.Store))
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func newCollector(g *gomega.GomegaWithT, cfg gc.Config, reader client.Reader, store *automock.Store) *gc.Collector {
+	collector, err := gc.New(cfg, logf.Log, reader, store)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	return collector
+}
+
+func fakeClient(g *gomega.GomegaWithT, objects ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	return fake.NewFakeClientWithScheme(scheme, objects...)
+}
+
+func fixBucket(namespace, name, remoteName string) *v1beta1.Bucket {
+	return &v1beta1.Bucket{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Status: v1beta1.BucketStatus{
+			CommonBucketStatus: v1beta1.CommonBucketStatus{
+				RemoteName: remoteName,
+			},
+		},
+	}
+}
+
+func fixClusterBucket(name, remoteName string) *v1beta1.ClusterBucket {
+	return &v1beta1.ClusterBucket{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Status: v1beta1.ClusterBucketStatus{
+			CommonBucketStatus: v1beta1.CommonBucketStatus{
+				RemoteName: remoteName,
+			},
+		},
+	}
+}
+
+func fixAsset(namespace, name, bucketName string) *v1beta1.Asset {
+	return &v1beta1.Asset{
+		ObjectMeta: v1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: v1beta1.AssetSpec{
+			CommonAssetSpec: v1beta1.CommonAssetSpec{
+				BucketRef: v1beta1.AssetBucketRef{Name: bucketName},
+			},
+		},
+	}
+}
+
+func fixClusterAsset(name, bucketName string) *v1beta1.ClusterAsset {
+	return &v1beta1.ClusterAsset{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1beta1.ClusterAssetSpec{
+			CommonAssetSpec: v1beta1.CommonAssetSpec{
+				BucketRef: v1beta1.AssetBucketRef{Name: bucketName},
+			},
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -26,6 +28,8 @@ type MetaAccessor interface {
 	GetNamespace() string
 	GetName() string
 	GetGeneration() int64
+	GetCreationTimestamp() v1.Time
+	GetAnnotations() map[string]string
 	GetDeletionTimestamp() *v1.Time
 	GetFinalizers() []string
 	SetFinalizers(finalizers []string)
@@ -37,29 +41,45 @@ var _ Handler = &assetHandler{}
 
 type FindBucketStatus func(ctx context.Context, namespace, name string) (*v1beta1.CommonBucketStatus, bool, error)
 
+type FindSecretKey func(ctx context.Context, namespace, name, key string) ([]byte, error)
+
+type FindServiceVersion func(ctx context.Context, namespace, name string) (string, error)
+
+type FindConfigMapVersion func(ctx context.Context, namespace, name string) (string, error)
+
 type assetHandler struct {
-	recorder          record.EventRecorder
-	findBucketStatus  FindBucketStatus
-	store             store.Store
-	loader            loader.Loader
-	validator         assethook.Validator
-	mutator           assethook.Mutator
-	metadataExtractor assethook.MetadataExtractor
-	log               logr.Logger
-	relistInterval    time.Duration
-}
-
-func New(log logr.Logger, recorder record.EventRecorder, store store.Store, loader loader.Loader, findBucketFnc FindBucketStatus, validator assethook.Validator, mutator assethook.Mutator, metadataExtractor assethook.MetadataExtractor, relistInterval time.Duration) Handler {
+	recorder             record.EventRecorder
+	findBucketStatus     FindBucketStatus
+	findSecretKey        FindSecretKey
+	findServiceVersion   FindServiceVersion
+	findConfigMapVersion FindConfigMapVersion
+	store                store.Store
+	loader               loader.Loader
+	validator            assethook.Validator
+	mutator              assethook.Mutator
+	metadataExtractor    assethook.MetadataExtractor
+	notifier             assethook.Notifier
+	log                  logr.Logger
+	relistInterval       time.Duration
+	retryPolicy          RetryPolicy
+}
+
+func New(log logr.Logger, recorder record.EventRecorder, store store.Store, loader loader.Loader, findBucketFnc FindBucketStatus, findSecretKeyFnc FindSecretKey, findServiceVersionFnc FindServiceVersion, findConfigMapVersionFnc FindConfigMapVersion, validator assethook.Validator, mutator assethook.Mutator, metadataExtractor assethook.MetadataExtractor, notifier assethook.Notifier, relistInterval time.Duration, retryPolicy RetryPolicy) Handler {
 	return &assetHandler{
-		recorder:          recorder,
-		store:             store,
-		loader:            loader,
-		findBucketStatus:  findBucketFnc,
-		validator:         validator,
-		mutator:           mutator,
-		metadataExtractor: metadataExtractor,
-		log:               log,
-		relistInterval:    relistInterval,
+		recorder:             recorder,
+		store:                store,
+		loader:               loader,
+		findBucketStatus:     findBucketFnc,
+		findSecretKey:        findSecretKeyFnc,
+		findServiceVersion:   findServiceVersionFnc,
+		findConfigMapVersion: findConfigMapVersionFnc,
+		validator:            validator,
+		mutator:              mutator,
+		metadataExtractor:    metadataExtractor,
+		notifier:             notifier,
+		log:                  log,
+		relistInterval:       relistInterval,
+		retryPolicy:          retryPolicy,
 	}
 }
 
@@ -67,20 +87,67 @@ func (h *assetHandler) Do(ctx context.Context, now time.Time, instance MetaAcces
 	h.logInfof("Start common Asset handling")
 	defer h.logInfof("Finish common Asset handling")
 
+	newStatus, err := h.do(ctx, now, instance, spec, status)
+	if newStatus != nil {
+		if newStatus.Reason != status.Reason || newStatus.ObservedGeneration != status.ObservedGeneration {
+			reasonsCounter.WithLabelValues(newStatus.Reason.String()).Inc()
+		}
+		if newStatus.LastResyncRequestedAt == "" {
+			newStatus.LastResyncRequestedAt = status.LastResyncRequestedAt
+		}
+		if newStatus.ConfigMapResourceVersion == "" && spec.Source.Mode == v1beta1.AssetConfigMap {
+			newStatus.ConfigMapResourceVersion = status.ConfigMapResourceVersion
+		}
+		if spec.DryRun {
+			// a dry run never publishes, so the reference to previously published content stays valid
+			newStatus.AssetRef = status.AssetRef
+		}
+		h.setRetry(instance, spec, status, newStatus, now)
+		h.setNotificationRetry(spec, status, newStatus, now)
+		if h.isWebhookFailure(newStatus.Reason) && newStatus.WebhooksFingerprint == "" {
+			fingerprint, fingerprintErr := h.getWebhooksFingerprint(ctx, spec)
+			if fingerprintErr != nil {
+				h.log.Error(fingerprintErr, "Unable to determine webhooks configuration")
+			}
+			newStatus.WebhooksFingerprint = fingerprint
+		}
+		h.setConditions(spec, status, newStatus, now)
+	}
+
+	return h.setExpiration(instance, spec, status, newStatus), err
+}
+
+func (h *assetHandler) do(ctx context.Context, now time.Time, instance MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
+
 	switch {
 	case h.isOnDelete(instance):
 		h.logInfof("On delete")
-		return h.onDelete(ctx, instance, spec)
+		return h.onDelete(ctx, now, instance, spec, status)
+	case h.isOnSuspended(spec):
+		h.logInfof("On suspended")
+		return h.onSuspended(instance, status), nil
 	case h.isOnAddOrUpdate(instance, status):
 		h.logInfof("On add or update")
-		return h.getStatus(instance, v1beta1.AssetPending, v1beta1.AssetScheduled), nil
+		return h.onAddOrUpdate(instance), nil
+	case h.isOnNotificationPending(status, now):
+		h.logInfof("On notification pending")
+		return h.onNotificationPending(ctx, instance, spec, status), nil
+	case h.isOnConfigMapReady(spec, status):
+		h.logInfof("On ConfigMap ready")
+		return h.onConfigMapReady(ctx, now, instance, spec, status)
 	case h.isOnReady(status, now):
 		h.logInfof("On ready")
 		return h.onReady(ctx, instance, spec, status)
+	case h.isOnBucketNotReady(status, now):
+		h.logInfof("On bucket not ready")
+		return h.onBucketNotReady(ctx, instance, spec, status)
 	case h.isOnPending(status, now):
 		h.logInfof("On pending")
 		return h.onPending(ctx, instance, spec, status)
-	case h.isOnFailed(status):
+	case h.isOnWebhookFailed(status):
+		h.logInfof("On webhook failed")
+		return h.onWebhookFailed(ctx, instance, spec, status)
+	case h.isOnFailed(spec, status, now):
 		h.logInfof("On failed")
 		return h.onPending(ctx, instance, spec, status)
 	default:
@@ -89,37 +156,151 @@ func (h *assetHandler) Do(ctx context.Context, now time.Time, instance MetaAcces
 	}
 }
 
-func (*assetHandler) isOnAddOrUpdate(object MetaAccessor, status v1beta1.CommonAssetStatus) bool {
-	return status.ObservedGeneration != object.GetGeneration()
+func (h *assetHandler) isOnAddOrUpdate(object MetaAccessor, status v1beta1.CommonAssetStatus) bool {
+	if status.ObservedGeneration != object.GetGeneration() {
+		return true
+	}
+
+	resyncRequestedAt := h.getResyncRequestedAt(object)
+	return resyncRequestedAt != "" && resyncRequestedAt != status.LastResyncRequestedAt
 }
 
 func (h *assetHandler) isOnPending(status v1beta1.CommonAssetStatus, now time.Time) bool {
-	if status.Phase == v1beta1.AssetPending {
-		if status.Reason == v1beta1.AssetBucketNotReady && now.Before(status.LastHeartbeatTime.Add(h.relistInterval)) {
-			return false
-		}
-
-		return true
-	}
+	return status.Phase == v1beta1.AssetPending && !h.isOnBucketNotReady(status, now)
+}
 
-	return false
+func (h *assetHandler) isOnBucketNotReady(status v1beta1.CommonAssetStatus, now time.Time) bool {
+	return status.Phase == v1beta1.AssetPending &&
+		status.Reason == v1beta1.AssetBucketNotReady &&
+		now.Before(status.LastHeartbeatTime.Add(h.relistInterval))
 }
 
 func (*assetHandler) isOnDelete(object MetaAccessor) bool {
 	return !object.GetDeletionTimestamp().IsZero()
 }
 
-func (*assetHandler) isOnFailed(status v1beta1.CommonAssetStatus) bool {
+func (*assetHandler) isOnSuspended(spec v1beta1.CommonAssetSpec) bool {
+	return spec.Suspend
+}
+
+func (h *assetHandler) isOnWebhookFailed(status v1beta1.CommonAssetStatus) bool {
+	return status.Phase == v1beta1.AssetFailed && h.isWebhookFailure(status.Reason)
+}
+
+func (h *assetHandler) isOnFailed(spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus, now time.Time) bool {
 	return status.Phase == v1beta1.AssetFailed &&
-		status.Reason != v1beta1.AssetValidationFailed &&
-		status.Reason != v1beta1.AssetMutationFailed
+		!h.isWebhookFailure(status.Reason) &&
+		h.isRetryAllowed(spec, status, now)
+}
+
+func (*assetHandler) isOnConfigMapReady(spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) bool {
+	return (status.Phase == v1beta1.AssetReady || status.Phase == v1beta1.AssetDryRun) && spec.Source.Mode == v1beta1.AssetConfigMap
 }
 
 func (h *assetHandler) isOnReady(status v1beta1.CommonAssetStatus, now time.Time) bool {
 	return status.Phase == v1beta1.AssetReady && now.After(status.LastHeartbeatTime.Add(h.relistInterval))
 }
 
-func (h *assetHandler) onDelete(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec) (*v1beta1.CommonAssetStatus, error) {
+func (h *assetHandler) onAddOrUpdate(object MetaAccessor) *v1beta1.CommonAssetStatus {
+	status := h.getStatus(object, v1beta1.AssetPending, v1beta1.AssetScheduled)
+	status.LastResyncRequestedAt = h.getResyncRequestedAt(object)
+
+	return status
+}
+
+func (*assetHandler) getResyncRequestedAt(object MetaAccessor) string {
+	return object.GetAnnotations()[v1beta1.ResyncRequestedAtAnnotation]
+}
+
+func (h *assetHandler) onSuspended(object MetaAccessor, status v1beta1.CommonAssetStatus) *v1beta1.CommonAssetStatus {
+	if status.Reason == v1beta1.AssetSuspended {
+		return nil
+	}
+
+	h.recordNormalEventf(object, v1beta1.AssetSuspended)
+	suspended := status.DeepCopy()
+	if suspended.Phase == "" {
+		suspended.Phase = v1beta1.AssetPending
+	}
+	suspended.Reason = v1beta1.AssetSuspended
+	suspended.Message = v1beta1.AssetSuspended.Message()
+	suspended.LastHeartbeatTime = v1.Now()
+
+	return suspended
+}
+
+// onConfigMapReady schedules the asset for processing when the source ConfigMap has changed
+func (h *assetHandler) onConfigMapReady(ctx context.Context, now time.Time, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
+	version, err := h.getConfigMapVersion(ctx, spec.Source.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != status.ConfigMapResourceVersion {
+		h.logInfof("ConfigMap %s has changed", spec.Source.URL)
+		return h.getStatus(object, v1beta1.AssetPending, v1beta1.AssetScheduled), nil
+	}
+	if !h.isOnReady(status, now) {
+		return nil, nil
+	}
+
+	return h.onReady(ctx, object, spec, status)
+}
+
+func (h *assetHandler) getConfigMapVersion(ctx context.Context, src string) (string, error) {
+	srcs := strings.Split(src, "/")
+	if len(srcs) != 2 {
+		return "", fmt.Errorf("%s: invalid source format", src)
+	}
+
+	version, err := h.findConfigMapVersion(ctx, srcs[0], srcs[1])
+	if err != nil {
+		return "", errors.Wrapf(err, "while getting ConfigMap %s", src)
+	}
+
+	return version, nil
+}
+
+// onBucketNotReady starts processing as soon as the bucket becomes ready, otherwise waits quietly until the next relist
+func (h *assetHandler) onBucketNotReady(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
+	_, isReady, err := h.findBucketStatus(ctx, object.GetNamespace(), spec.BucketRef.Name)
+	if err != nil || !isReady {
+		return nil, nil
+	}
+
+	return h.onPending(ctx, object, spec, status)
+}
+
+func (h *assetHandler) onWebhookFailed(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
+	fingerprint, err := h.getWebhooksFingerprint(ctx, spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "while checking webhooks configuration")
+	}
+
+	if status.WebhooksFingerprint == "" {
+		updated := status.DeepCopy()
+		updated.WebhooksFingerprint = fingerprint
+		return updated, nil
+	}
+	if status.WebhooksFingerprint == fingerprint {
+		return nil, nil
+	}
+
+	h.logInfof("Webhooks configuration changed, retrying")
+	return h.onPending(ctx, object, spec, status)
+}
+
+// onDelete removes the remote content. A status with a pending notification is returned while the deletion notification
+// is retried and the asset must be kept.
+func (h *assetHandler) onDelete(ctx context.Context, now time.Time, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
+	if pending := status.PendingNotification; pending != nil && pending.Event == v1beta1.AssetNotificationDeleted {
+		if now.Before(pending.NextAttemptTime.Time) {
+			return status.DeepCopy(), nil
+		}
+		h.logInfof("Retrying deletion notification")
+		return h.onNotificationPending(ctx, object, spec, status), nil
+	}
+
 	h.logInfof("Deleting Asset")
 	bucketStatus, isReady, err := h.findBucketStatus(ctx, object.GetNamespace(), spec.BucketRef.Name)
 	if err != nil {
@@ -129,18 +310,51 @@ func (h *assetHandler) onDelete(ctx context.Context, object MetaAccessor, spec v
 		h.logInfof("Nothing to delete, bucket %s is not ready", spec.BucketRef.Name)
 		return nil, nil
 	}
+	if bucketStatus.DeletionPolicy == v1beta1.BucketDeletionPolicyRetain {
+		h.logInfof("Remote content retained due to deletion policy of bucket %s", spec.BucketRef.Name)
+		return nil, nil
+	}
 
-	if err := h.deleteRemoteContent(ctx, object, bucketStatus.RemoteName); err != nil {
+	if err := h.deleteRemoteContent(ctx, object, spec, bucketStatus.RemoteName); err != nil {
 		return nil, err
 	}
 	h.logInfof("Asset deleted")
+	pending := h.notify(ctx, object, spec, v1beta1.AssetNotificationDeleted, h.getBaseUrl(bucketStatus.URL, h.getObjectPrefix(object, spec)), nil)
+	if pending == nil {
+		return nil, nil
+	}
 
-	return nil, nil
+	deletingStatus := status.DeepCopy()
+	deletingStatus.PendingNotification = pending
+	return deletingStatus, nil
 }
 
-func (h *assetHandler) deleteRemoteContent(ctx context.Context, object MetaAccessor, bucketName string) error {
+// prepareBucket checks if the bucket is ready and removes the previous content of the asset. A status is returned when processing can't continue.
+func (h *assetHandler) prepareBucket(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec) (*v1beta1.CommonBucketStatus, *v1beta1.CommonAssetStatus, error) {
+	h.logInfof("Checking if bucket %s is ready", spec.BucketRef.Name)
+	bucketStatus, isReady, err := h.findBucketStatus(ctx, object.GetNamespace(), spec.BucketRef.Name)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetBucketError, err.Error())
+		return nil, h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetBucketError, err.Error()), err
+	}
+	if !isReady {
+		h.logInfof("Bucket %s is not ready", spec.BucketRef.Name)
+		h.recordWarningEventf(object, v1beta1.AssetBucketNotReady)
+		return nil, h.getStatus(object, v1beta1.AssetPending, v1beta1.AssetBucketNotReady), nil
+	}
+	h.logInfof("Bucket %s is ready", spec.BucketRef.Name)
+
+	if err := h.deleteRemoteContent(ctx, object, spec, bucketStatus.RemoteName); err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
+		return nil, h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetCleanupError, err.Error()), err
+	}
+
+	return bucketStatus, nil, nil
+}
+
+func (h *assetHandler) deleteRemoteContent(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, bucketName string) error {
 	h.logInfof("Checking if bucket contains files for asset")
-	prefix := object.GetName()
+	prefix := h.getObjectPrefix(object, spec)
 	files, err := h.store.ListObjects(ctx, bucketName, prefix)
 	if err != nil {
 		return errors.Wrap(err, "while listing files in bucket")
@@ -162,6 +376,11 @@ func (h *assetHandler) deleteRemoteContent(ctx context.Context, object MetaAcces
 }
 
 func (h *assetHandler) onReady(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
+	if spec.DryRun {
+		h.logInfof("Asset is in dry-run mode, remote content is not verified")
+		return nil, nil
+	}
+
 	h.logInfof("Checking if bucket %s is ready", spec.BucketRef.Name)
 	bucketStatus, isReady, err := h.findBucketStatus(ctx, object.GetNamespace(), spec.BucketRef.Name)
 	if err != nil {
@@ -175,53 +394,76 @@ func (h *assetHandler) onReady(ctx context.Context, object MetaAccessor, spec v1
 	}
 	h.logInfof("Bucket %s is ready", spec.BucketRef.Name)
 
-	h.logInfof("Checking if store contains all files")
-	exists, err := h.store.ContainsAllObjects(ctx, bucketStatus.RemoteName, object.GetName(), h.extractNames(status.AssetRef.Files))
+	encryption, err := h.getEncryption(ctx, object, spec, bucketStatus.Encryption)
 	if err != nil {
 		h.recordWarningEventf(object, v1beta1.AssetRemoteContentVerificationError, err.Error())
 		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetRemoteContentVerificationError, err.Error()), err
 	}
-	if !exists {
+
+	h.logInfof("Verifying remote content")
+	verification, err := h.store.VerifyObjects(ctx, bucketStatus.RemoteName, h.getObjectPrefix(object, spec), h.extractDigests(status.AssetRef.Files), encryption)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetRemoteContentVerificationError, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetRemoteContentVerificationError, err.Error()), err
+	}
+	if len(verification.Missing) > 0 {
 		h.recordWarningEventf(object, v1beta1.AssetMissingContent)
-		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetMissingContent), err
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetMissingContent), nil
+	}
+	if len(verification.Modified) > 0 {
+		modified := strings.Join(verification.Modified, ", ")
+		h.recordWarningEventf(object, v1beta1.AssetContentModified, modified)
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetContentModified, modified), nil
+	}
+	if len(verification.Unexpected) > 0 {
+		h.recordWarningEventf(object, v1beta1.AssetUnexpectedContent, strings.Join(verification.Unexpected, ", "))
 	}
 
 	h.logInfof("Asset is up-to-date")
+	readyStatus := h.getReadyStatus(object, status.AssetRef.BaseURL, status.AssetRef.Files, v1beta1.AssetUploaded)
+	readyStatus.WebhookWarnings = status.WebhookWarnings
+	readyStatus.PendingNotification = status.PendingNotification
 
-	return h.getReadyStatus(object, status.AssetRef.BaseURL, status.AssetRef.Files, v1beta1.AssetUploaded), nil
+	return readyStatus, nil
 }
 
-func (h *assetHandler) extractNames(files []v1beta1.AssetFile) []string {
-	names := make([]string, 0, len(files))
+func (h *assetHandler) extractDigests(files []v1beta1.AssetFile) map[string]store.ObjectDigest {
+	digests := make(map[string]store.ObjectDigest, len(files))
 
 	for _, file := range files {
-		names = append(names, file.Name)
+		digests[file.Name] = store.ObjectDigest{Size: file.Size, Md5: file.Md5, Sha256: file.Sha256}
 	}
 
-	return names
+	return digests
 }
 
 func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) (*v1beta1.CommonAssetStatus, error) {
-	h.logInfof("Checking if bucket %s is ready", spec.BucketRef.Name)
-	bucketStatus, isReady, err := h.findBucketStatus(ctx, object.GetNamespace(), spec.BucketRef.Name)
-	if err != nil {
-		h.recordWarningEventf(object, v1beta1.AssetBucketError, err.Error())
-		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetBucketError, err.Error()), err
-	}
-	if !isReady {
-		h.logInfof("Bucket %s is not ready", spec.BucketRef.Name)
-		h.recordWarningEventf(object, v1beta1.AssetBucketNotReady)
-		return h.getStatus(object, v1beta1.AssetPending, v1beta1.AssetBucketNotReady), nil
+	var bucketStatus *v1beta1.CommonBucketStatus
+	if spec.DryRun {
+		h.logInfof("Processing Asset in dry-run mode, bucket %s is not used", spec.BucketRef.Name)
+	} else {
+		var pendingStatus *v1beta1.CommonAssetStatus
+		var err error
+		bucketStatus, pendingStatus, err = h.prepareBucket(ctx, object, spec)
+		if pendingStatus != nil {
+			return pendingStatus, err
+		}
 	}
-	h.logInfof("Bucket %s is ready", spec.BucketRef.Name)
 
-	if err := h.deleteRemoteContent(ctx, object, bucketStatus.RemoteName); err != nil {
-		h.recordWarningEventf(object, v1beta1.AssetCleanupError, err.Error())
-		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetCleanupError, err.Error()), err
+	var configMapVersion string
+	if spec.Source.Mode == v1beta1.AssetConfigMap {
+		var err error
+		configMapVersion, err = h.getConfigMapVersion(ctx, spec.Source.URL)
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.AssetPullingFailed, err.Error())
+			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetPullingFailed, err.Error()), err
+		}
 	}
 
 	h.logInfof("Loading files from %s", spec.Source.URL)
+	start := time.Now()
 	basePath, filenames, err := h.loader.Load(spec.Source.URL, object.GetName(), spec.Source.Mode, spec.Source.Filter)
+	observeStage(stageLoad, start, err, true)
 	defer h.loader.Clean(basePath)
 	if err != nil {
 		h.recordWarningEventf(object, v1beta1.AssetPullingFailed, err.Error())
@@ -230,9 +472,14 @@ func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec
 	h.logInfof("Files loaded")
 	h.recordNormalEventf(object, v1beta1.AssetPulled)
 
+	var webhookMessages map[string][]assethook.Message
+	var webhookWarnings []string
+
 	if len(spec.Source.MutationWebhookService) > 0 {
 		h.logInfof("Mutating Asset content")
+		start := time.Now()
 		result, err := h.mutator.Mutate(ctx, basePath, filenames, spec.Source.MutationWebhookService)
+		observeStage(stageMutation, start, err, result.Success)
 		if err != nil {
 			h.recordWarningEventf(object, v1beta1.AssetMutationFailed, err.Error())
 			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetMutationError, err.Error()), err
@@ -241,13 +488,17 @@ func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec
 			h.recordWarningEventf(object, v1beta1.AssetMutationFailed, result.Messages)
 			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetMutationFailed, result.Messages), nil
 		}
+		webhookMessages = h.appendWebhookMessages(webhookMessages, result.Messages)
+		webhookWarnings = h.appendWebhookWarnings(object, webhookWarnings, result.Warnings)
 		h.logInfof("Asset content mutated")
 		h.recordNormalEventf(object, v1beta1.AssetMutated)
 	}
 
 	if len(spec.Source.ValidationWebhookService) > 0 {
 		h.logInfof("Validating Asset content")
+		start := time.Now()
 		result, err := h.validator.Validate(ctx, basePath, filenames, spec.Source.ValidationWebhookService)
+		observeStage(stageValidation, start, err, result.Success)
 		if err != nil {
 			h.recordWarningEventf(object, v1beta1.AssetValidationError, err.Error())
 			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetValidationError, err.Error()), err
@@ -256,6 +507,8 @@ func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec
 			h.recordWarningEventf(object, v1beta1.AssetValidationFailed, result.Messages)
 			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetValidationFailed, result.Messages), nil
 		}
+		webhookMessages = h.appendWebhookMessages(webhookMessages, result.Messages)
+		webhookWarnings = h.appendWebhookWarnings(object, webhookWarnings, result.Warnings)
 		h.logInfof("Asset content validated")
 		h.recordNormalEventf(object, v1beta1.AssetValidated)
 	}
@@ -263,27 +516,98 @@ func (h *assetHandler) onPending(ctx context.Context, object MetaAccessor, spec
 	files := h.populateFiles(filenames)
 	if len(spec.Source.MetadataWebhookService) > 0 {
 		h.logInfof("Extracting metadata from Assets content")
-		result, err := h.metadataExtractor.Extract(ctx, basePath, filenames, spec.Source.MetadataWebhookService)
+		start := time.Now()
+		result, warnings, err := h.metadataExtractor.Extract(ctx, basePath, filenames, spec.Source.MetadataWebhookService)
+		observeStage(stageMetadataExtraction, start, err, true)
 		if err != nil {
 			h.recordWarningEventf(object, v1beta1.AssetMetadataExtractionFailed, err.Error())
 			return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetMetadataExtractionFailed, err.Error()), err
 		}
 
 		files = h.mergeMetadata(files, result)
+		webhookWarnings = h.appendWebhookWarnings(object, webhookWarnings, warnings)
 
 		h.logInfof("Metadata extracted")
 		h.recordNormalEventf(object, v1beta1.AssetMetadataExtracted)
 	}
 
+	if spec.DryRun {
+		h.logInfof("Asset content processed in dry-run mode, nothing has been published")
+		h.recordNormalEventf(object, v1beta1.AssetDryRunSucceeded)
+		dryRunStatus := h.getStatus(object, v1beta1.AssetDryRun, v1beta1.AssetDryRunSucceeded)
+		dryRunStatus.DryRunFiles = files
+		dryRunStatus.ConfigMapResourceVersion = configMapVersion
+		dryRunStatus.WebhookWarnings = webhookWarnings
+		dryRunStatus.WebhookMessages = h.toWebhookMessages(webhookMessages)
+
+		return dryRunStatus, nil
+	}
+
+	encryption, err := h.getEncryption(ctx, object, spec, bucketStatus.Encryption)
+	if err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetUploadFailed, err.Error())
+		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetUploadFailed, err.Error()), err
+	}
+
 	h.logInfof("Uploading Asset content to Minio")
-	if err := h.store.PutObjects(ctx, bucketStatus.RemoteName, object.GetName(), basePath, filenames); err != nil {
+	start = time.Now()
+	uploaded, err := h.store.PutObjects(ctx, bucketStatus.RemoteName, h.getObjectPrefix(object, spec), basePath, filenames, encryption)
+	observeStage(stageUpload, start, err, true)
+	if err != nil {
 		h.recordWarningEventf(object, v1beta1.AssetUploadFailed, err.Error())
 		return h.getStatus(object, v1beta1.AssetFailed, v1beta1.AssetUploadFailed, err.Error()), err
 	}
+	for _, info := range uploaded {
+		uploadedBytesCounter.Add(float64(info.Size))
+	}
+	baseUrl := h.getBaseUrl(bucketStatus.URL, h.getObjectPrefix(object, spec))
+	files = h.mergeFileInfo(files, uploaded, baseUrl)
 	h.logInfof("Asset content uploaded")
 	h.recordNormalEventf(object, v1beta1.AssetUploaded)
+	pending := h.notify(ctx, object, spec, v1beta1.AssetNotificationUploaded, baseUrl, files)
+
+	readyStatus := h.getReadyStatus(object, baseUrl, files, v1beta1.AssetUploaded)
+	readyStatus.PendingNotification = pending
+	readyStatus.ConfigMapResourceVersion = configMapVersion
+	readyStatus.WebhookWarnings = webhookWarnings
 
-	return h.getReadyStatus(object, h.getBaseUrl(bucketStatus.URL, object.GetName()), files, v1beta1.AssetUploaded), nil
+	return readyStatus, nil
+}
+
+// getObjectPrefix namespaces the remote objects of Assets stored in a shared ClusterBucket
+func (*assetHandler) getObjectPrefix(object MetaAccessor, spec v1beta1.CommonAssetSpec) string {
+	if object.GetNamespace() == "" || spec.BucketRef.Kind != v1beta1.AssetBucketRefKindClusterBucket {
+		return object.GetName()
+	}
+
+	return fmt.Sprintf("%s/%s/%s", store.NamespacedObjectsPrefix, object.GetNamespace(), object.GetName())
+}
+
+func (h *assetHandler) getEncryption(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, encryption *v1beta1.BucketEncryption) (*store.Encryption, error) {
+	if encryption == nil {
+		return nil, nil
+	}
+
+	result := &store.Encryption{Mode: encryption.Mode}
+	if encryption.Mode != v1beta1.BucketEncryptionSSEC {
+		return result, nil
+	}
+
+	ref := encryption.KeySecretRef
+	if ref == nil {
+		return nil, errors.New("missing encryption key reference")
+	}
+	if object.GetNamespace() != "" && spec.BucketRef.Kind != v1beta1.AssetBucketRefKindClusterBucket && ref.Namespace != object.GetNamespace() {
+		return nil, errors.Errorf("encryption key of a bucket in namespace %s cannot be read from namespace %s", object.GetNamespace(), ref.Namespace)
+	}
+
+	key, err := h.findSecretKey(ctx, ref.Namespace, ref.Name, ref.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading encryption key from secret %s/%s", ref.Namespace, ref.Name)
+	}
+	result.Key = key
+
+	return result, nil
 }
 
 func (h *assetHandler) populateFiles(filenames []string) []v1beta1.AssetFile {
@@ -296,6 +620,59 @@ func (h *assetHandler) populateFiles(filenames []string) []v1beta1.AssetFile {
 	return result
 }
 
+func (*assetHandler) appendWebhookMessages(messages, newMessages map[string][]assethook.Message) map[string][]assethook.Message {
+	if len(newMessages) == 0 {
+		return messages
+	}
+	if messages == nil {
+		messages = make(map[string][]assethook.Message)
+	}
+	for name, fileMessages := range newMessages {
+		messages[name] = append(messages[name], fileMessages...)
+	}
+
+	return messages
+}
+
+// toWebhookMessages flattens messages grouped by webhook name into a list sorted by webhook and file name
+func (*assetHandler) toWebhookMessages(messages map[string][]assethook.Message) []v1beta1.AssetWebhookMessage {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(messages))
+	for name := range messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []v1beta1.AssetWebhookMessage
+	for _, name := range names {
+		fileMessages := append([]assethook.Message(nil), messages[name]...)
+		sort.SliceStable(fileMessages, func(i, j int) bool {
+			return fileMessages[i].Filename < fileMessages[j].Filename
+		})
+		for _, message := range fileMessages {
+			result = append(result, v1beta1.AssetWebhookMessage{
+				Webhook:  name,
+				Filename: message.Filename,
+				Message:  message.Message,
+			})
+		}
+	}
+
+	return result
+}
+
+// appendWebhookWarnings records failures of webhooks ignored due to their failure policy
+func (h *assetHandler) appendWebhookWarnings(object MetaAccessor, warnings, newWarnings []string) []string {
+	for _, warning := range newWarnings {
+		h.recordWarningEventf(object, v1beta1.AssetWebhookFailureIgnored, warning)
+	}
+
+	return append(warnings, newWarnings...)
+}
+
 func (h *assetHandler) mergeMetadata(files []v1beta1.AssetFile, metadatas []assethook.File) []v1beta1.AssetFile {
 	metadataMap := make(map[string]*json.RawMessage)
 	for _, metadata := range metadatas {
@@ -312,6 +689,21 @@ func (h *assetHandler) mergeMetadata(files []v1beta1.AssetFile, metadatas []asse
 	return result
 }
 
+func (h *assetHandler) mergeFileInfo(files []v1beta1.AssetFile, uploaded map[string]store.FileInfo, baseUrl string) []v1beta1.AssetFile {
+	result := make([]v1beta1.AssetFile, 0, len(files))
+	for _, file := range files {
+		info := uploaded[file.Name]
+		file.Md5 = info.Md5
+		file.Sha256 = info.Sha256
+		file.Size = info.Size
+		file.ContentType = info.ContentType
+		file.URL = fmt.Sprintf("%s/%s", baseUrl, file.Name)
+		result = append(result, file)
+	}
+
+	return result
+}
+
 func (h *assetHandler) toRawExtension(message *json.RawMessage) *runtime.RawExtension {
 	if message == nil {
 		return nil
@@ -344,6 +736,10 @@ func (h *assetHandler) getReadyStatus(object MetaAccessor, baseUrl string, files
 	status := h.getStatus(object, v1beta1.AssetReady, reason, args...)
 	status.AssetRef.BaseURL = baseUrl
 	status.AssetRef.Files = files
+	status.AssetRef.FileCount = len(files)
+	for _, file := range files {
+		status.AssetRef.TotalSize += file.Size
+	}
 	return status
 }
 
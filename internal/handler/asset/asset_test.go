@@ -7,9 +7,11 @@ import (
 	"time"
 
 	engine "github.com/kyma-project/rafter/internal/assethook"
+	hookApi "github.com/kyma-project/rafter/internal/assethook/api/v1alpha1"
 	engineMock "github.com/kyma-project/rafter/internal/assethook/automock"
 	"github.com/kyma-project/rafter/internal/handler/asset"
 	loaderMock "github.com/kyma-project/rafter/internal/loader/automock"
+	"github.com/kyma-project/rafter/internal/store"
 	storeMock "github.com/kyma-project/rafter/internal/store/automock"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	. "github.com/onsi/gomega"
@@ -17,6 +19,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
@@ -24,6 +27,7 @@ var log = logf.Log.WithName("asset-test")
 
 const (
 	remoteBucketName = "bucket-name"
+	encryptionKey    = "01234567890123456789012345678901"
 )
 
 func TestAssetHandler_Handle_OnAddOrUpdate(t *testing.T) {
@@ -91,6 +95,38 @@ func TestAssetHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
 		g.Expect(status.Reason).To(Equal(v1beta1.AssetScheduled))
 	})
+
+	t.Run("OnResync", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.ObjectMeta.Generation = int64(1)
+		asset.Annotations = map[string]string{v1beta1.ResyncRequestedAtAnnotation: "2"}
+		asset.Status.ObservedGeneration = int64(1)
+		asset.Status.Phase = v1beta1.AssetReady
+		asset.Status.Reason = v1beta1.AssetUploaded
+		asset.Status.LastHeartbeatTime = v1.NewTime(now)
+		asset.Status.LastResyncRequestedAt = "1"
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetScheduled))
+		g.Expect(status.LastResyncRequestedAt).To(Equal("2"))
+		g.Expect(conditionsOf(status)).To(Equal(map[v1beta1.AssetConditionType]v1beta1.AssetReason{
+			v1beta1.AssetConditionReady: v1beta1.AssetScheduled,
+		}))
+	})
 }
 
 func TestAssetHandler_Handle_Default(t *testing.T) {
@@ -114,6 +150,29 @@ func TestAssetHandler_Handle_Default(t *testing.T) {
 	g.Expect(status).To(BeZero())
 }
 
+func TestAssetHandler_Handle_ResyncHandled(t *testing.T) {
+	// Given
+	g := NewGomegaWithT(t)
+	ctx := context.TODO()
+	relistInterval := time.Minute
+	now := time.Now()
+	asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+	asset.ObjectMeta.Generation = int64(1)
+	asset.Annotations = map[string]string{v1beta1.ResyncRequestedAtAnnotation: "1"}
+	asset.Status.ObservedGeneration = int64(1)
+	asset.Status.LastResyncRequestedAt = "1"
+
+	handler, mocks := newHandler(relistInterval)
+	defer mocks.AssertExpectations(t)
+
+	// When
+	status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+	// Then
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status).To(BeZero())
+}
+
 func TestAssetHandler_Handle_OnReady(t *testing.T) {
 	t.Run("NotTaken", func(t *testing.T) {
 		// Given
@@ -150,7 +209,7 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(true, nil).Once()
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(&store.Verification{}, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -162,6 +221,33 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
 	})
 
+	t.Run("NotChangedReasonNotCounted", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetUploaded
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(&store.Verification{}, nil).Once()
+		before := reasonsTotal(g, v1beta1.AssetUploaded)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(reasonsTotal(g, v1beta1.AssetUploaded)).To(Equal(before))
+	})
+
 	t.Run("BucketNotReady", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
@@ -223,7 +309,7 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(false, nil).Once()
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(&store.Verification{Missing: []string{"test.md"}}, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -235,7 +321,58 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(status.Reason).To(Equal(v1beta1.AssetMissingContent))
 	})
 
-	t.Run("ContainsError", func(t *testing.T) {
+	t.Run("ModifiedFiles", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(&store.Verification{Modified: []string{"test.md"}}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetContentModified))
+		g.Expect(status.Message).To(ContainSubstring("test.md"))
+	})
+
+	t.Run("UnexpectedFiles", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(&store.Verification{Unexpected: []string{"extra.md"}}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("VerificationError", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
@@ -248,7 +385,1149 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
-		mocks.store.On("ContainsAllObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("[]string")).Return(false, errors.New("nope")).Once()
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(nil, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetRemoteContentVerificationError))
+	})
+}
+
+func TestAssetHandler_Handle_OnConfigMapReady(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "test-namespace/test-configmap")
+		asset.Spec.Source.Mode = v1beta1.AssetConfigMap
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now)
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset.Status.CommonAssetStatus.ConfigMapResourceVersion = "1"
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.configMapVersions["test-configmap"] = "2"
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetScheduled))
+	})
+
+	t.Run("NotChanged", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "test-namespace/test-configmap")
+		asset.Spec.Source.Mode = v1beta1.AssetConfigMap
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now)
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset.Status.CommonAssetStatus.ConfigMapResourceVersion = "1"
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.configMapVersions["test-configmap"] = "1"
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+}
+
+func TestAssetHandler_Handle_OnPending(t *testing.T) {
+	t.Run("WithWebhooks", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("IgnoredWebhookFailures", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true, Warnings: []string{"mutation down"}}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, []string{"metadata down"}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.WebhookWarnings).To(Equal([]string{"mutation down", "metadata down"}))
+	})
+
+	t.Run("WithoutWebhooks", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("ConfigMapVersion", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "test-namespace/test-configmap")
+		asset.Spec.Source.Mode = v1beta1.AssetConfigMap
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.configMapVersions["test-configmap"] = "3"
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.ConfigMapResourceVersion).To(Equal("3"))
+	})
+
+	t.Run("ClusterBucket", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Namespace = "test-ns"
+		asset.Spec.BucketRef.Kind = v1beta1.AssetBucketRefKindClusterBucket
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		prefix := "_namespaces/test-ns/test-asset"
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, prefix).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, prefix, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.BaseURL).To(HaveSuffix(prefix))
+	})
+
+	t.Run("FileInfo", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		files := []string{"a.json", "docs/b.md"}
+		uploaded := map[string]store.FileInfo{
+			"a.json":    {Size: 10, Sha256: "digest-a", ContentType: "application/json"},
+			"docs/b.md": {Size: 20, Sha256: "digest-b", ContentType: "text/markdown"},
+		}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", files, (*store.Encryption)(nil)).Return(uploaded, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", files, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.AssetRef.FileCount).To(Equal(2))
+		g.Expect(status.AssetRef.TotalSize).To(Equal(int64(30)))
+		g.Expect(status.AssetRef.Files).To(ConsistOf(
+			v1beta1.AssetFile{Name: "a.json", Size: 10, Sha256: "digest-a", ContentType: "application/json", URL: "http://test-url.com/bucket-name/test-asset/a.json"},
+			v1beta1.AssetFile{Name: "docs/b.md", Size: 20, Sha256: "digest-b", ContentType: "text/markdown", URL: "http://test-url.com/bucket-name/test-asset/docs/b.md"},
+		))
+	})
+
+	t.Run("WithEncryption", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "encrypted", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		encryption := &store.Encryption{Mode: v1beta1.BucketEncryptionSSEC, Key: []byte(encryptionKey)}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), encryption).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("EncryptionKeyError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "encrypted-missing", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+	})
+
+	t.Run("EncryptionKeyInOtherNamespace", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "foreign-key", "https://localhost/test.md")
+		asset.Namespace = "test-namespace"
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+	})
+
+	t.Run("LoadError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("nope")).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetPullingFailed))
+	})
+
+	t.Run("MutationFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationFailed))
+	})
+
+	t.Run("MutationError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationError))
+	})
+
+	t.Run("MetadataExtractionFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetMetadataExtractionFailed))
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationError))
+	})
+
+	t.Run("ValidationFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationFailed))
+	})
+
+	t.Run("UploadError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, errors.New("nope")).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+	})
+
+	t.Run("BucketNotReady", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "notReady", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketNotReady))
+	})
+
+	t.Run("BucketStatusError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "error", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketError))
+	})
+
+	t.Run("OnBucketNotReadyBeforeTime", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "error", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketNotReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.Now()
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("OnBucketReadyBeforeTime", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketNotReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.Now()
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+}
+
+func TestAssetHandler_Handle_DryRun(t *testing.T) {
+	t.Run("OnPending", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "notReady", "https://localhost/test.md")
+		asset.Spec.DryRun = true
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.AssetRef.BaseURL = "https://minio.local/test-bucket/test-asset"
+		asset.Status.AssetRef.Files = []v1beta1.AssetFile{{Name: "published.md"}}
+		messages := map[string][]engine.Message{"validator": {{Filename: "test.md", Message: "deprecated field"}}}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", []string{"test.md"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true, Messages: messages}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetDryRun))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetDryRunSucceeded))
+		g.Expect(status.AssetRef).To(Equal(asset.Status.AssetRef))
+		g.Expect(status.DryRunFiles).To(HaveLen(1))
+		g.Expect(status.WebhookMessages).To(Equal([]v1beta1.AssetWebhookMessage{
+			{Webhook: "validator", Filename: "test.md", Message: "deprecated field"},
+		}))
+		g.Expect(conditionsOf(status)).To(Equal(map[v1beta1.AssetConditionType]v1beta1.AssetReason{
+			v1beta1.AssetConditionSourcePulled:      v1beta1.AssetPulled,
+			v1beta1.AssetConditionMutated:           v1beta1.AssetMutated,
+			v1beta1.AssetConditionValidated:         v1beta1.AssetValidated,
+			v1beta1.AssetConditionMetadataExtracted: v1beta1.AssetMetadataExtracted,
+			v1beta1.AssetConditionReady:             v1beta1.AssetDryRunSucceeded,
+		}))
+		g.Expect(status.Conditions[len(status.Conditions)-1].Status).To(Equal(v1.ConditionFalse))
+	})
+
+	t.Run("OnReady", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.DryRun = true
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetDryRun
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetDryRunSucceeded
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+}
+
+func TestAssetHandler_Handle_Notification(t *testing.T) {
+	t.Run("Uploaded", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 2)
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", []string{"test.md"}, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.notifier.On("Notify", ctx, mock.MatchedBy(func(notification hookApi.NotificationRequest) bool {
+			return notification.Event == hookApi.NotificationUploaded &&
+				notification.Name == asset.Name &&
+				notification.Generation == asset.Generation &&
+				notification.BaseURL != "" &&
+				len(notification.Files) == 1
+		}), asset.Spec.Source.NotificationWebhookService).Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.notifier.On("Notify", ctx, mock.Anything, asset.Spec.Source.NotificationWebhookService).Return(errors.New("test-error")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.PendingNotification).ToNot(BeNil())
+		g.Expect(status.PendingNotification.Event).To(Equal(v1beta1.AssetNotificationUploaded))
+		g.Expect(status.PendingNotification.Attempts).To(Equal(1))
+	})
+
+	t.Run("PendingRetried", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetUploaded
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now)
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.AssetRef.Files = []v1beta1.AssetFile{{Name: "test.md"}}
+		asset.Status.PendingNotification = &v1beta1.AssetPendingNotification{
+			Event:           v1beta1.AssetNotificationUploaded,
+			BaseURL:         "https://minio.local/test-bucket/test-asset",
+			Attempts:        1,
+			NextAttemptTime: v1.NewTime(now.Add(-time.Second)),
+		}
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.notifier.On("Notify", ctx, mock.MatchedBy(func(notification hookApi.NotificationRequest) bool {
+			return notification.Event == hookApi.NotificationUploaded &&
+				notification.BaseURL == "https://minio.local/test-bucket/test-asset" &&
+				len(notification.Files) == 1
+		}), asset.Spec.Source.NotificationWebhookService).Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.PendingNotification).To(BeNil())
+	})
+
+	t.Run("PendingFailedAgain", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		retryPolicy := asset.RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Hour}
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetUploaded
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now)
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.PendingNotification = &v1beta1.AssetPendingNotification{
+			Event:           v1beta1.AssetNotificationUploaded,
+			Attempts:        2,
+			NextAttemptTime: v1.NewTime(now),
+		}
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+
+		handler, mocks := newHandlerWithRetryPolicy(relistInterval, retryPolicy)
+		defer mocks.AssertExpectations(t)
+		mocks.notifier.On("Notify", ctx, mock.Anything, asset.Spec.Source.NotificationWebhookService).Return(errors.New("test-error")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.PendingNotification).ToNot(BeNil())
+		g.Expect(status.PendingNotification.Attempts).To(Equal(3))
+		g.Expect(status.PendingNotification.Message).To(ContainSubstring("test-error"))
+		g.Expect(status.PendingNotification.NextAttemptTime.Time).To(BeTemporally("==", now.Add(4*time.Second)))
+	})
+
+	t.Run("PendingAbandoned", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.RetryPolicy = &v1beta1.AssetRetryPolicy{MaxAttempts: 3}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetUploaded
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now)
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.PendingNotification = &v1beta1.AssetPendingNotification{
+			Event:           v1beta1.AssetNotificationUploaded,
+			Attempts:        3,
+			NextAttemptTime: v1.NewTime(now),
+		}
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.PendingNotification).To(BeNil())
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		nowMeta := v1.Now()
+		asset.ObjectMeta.DeletionTimestamp = &nowMeta
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return([]string{"test/a.txt"}, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name).Return(nil).Once()
+		mocks.notifier.On("Notify", ctx, mock.MatchedBy(func(notification hookApi.NotificationRequest) bool {
+			return notification.Event == hookApi.NotificationDeleted && notification.Name == asset.Name && len(notification.Files) == 0
+		}), asset.Spec.Source.NotificationWebhookService).Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("DeletedFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		nowMeta := v1.Now()
+		asset.ObjectMeta.DeletionTimestamp = &nowMeta
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return([]string{"test/a.txt"}, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name).Return(nil).Once()
+		mocks.notifier.On("Notify", ctx, mock.Anything, asset.Spec.Source.NotificationWebhookService).Return(errors.New("test-error")).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.PendingNotification).ToNot(BeNil())
+		g.Expect(status.PendingNotification.Event).To(Equal(v1beta1.AssetNotificationDeleted))
+		g.Expect(status.PendingNotification.Attempts).To(Equal(1))
+	})
+
+	t.Run("DeletedPendingNotDue", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		nowMeta := v1.Now()
+		asset.ObjectMeta.DeletionTimestamp = &nowMeta
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+		asset.Status.PendingNotification = &v1beta1.AssetPendingNotification{
+			Event:           v1beta1.AssetNotificationDeleted,
+			Attempts:        1,
+			NextAttemptTime: v1.NewTime(now.Add(time.Minute)),
+		}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.PendingNotification).To(Equal(asset.Status.PendingNotification))
+	})
+
+	t.Run("DeletedPendingAbandoned", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		nowMeta := v1.Now()
+		asset.ObjectMeta.DeletionTimestamp = &nowMeta
+		asset.Spec.Source.NotificationWebhookService = make([]v1beta1.WebhookService, 1)
+		asset.Status.PendingNotification = &v1beta1.AssetPendingNotification{
+			Event:           v1beta1.AssetNotificationDeleted,
+			Attempts:        5,
+			NextAttemptTime: v1.NewTime(now),
+		}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.PendingNotification).To(BeNil())
+	})
+}
+
+func TestAssetHandler_Handle_OnFailed(t *testing.T) {
+	t.Run("ShouldHandle", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketError
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+	})
+
+	t.Run("ValidationFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetValidationFailed
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		fingerprinted, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fingerprinted).ToNot(BeZero())
+		g.Expect(fingerprinted.Reason).To(Equal(v1beta1.AssetValidationFailed))
+		g.Expect(fingerprinted.WebhooksFingerprint).ToNot(BeEmpty())
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, *fingerprinted)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("MutationFailed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetMutationFailed
+		asset.Status.ObservedGeneration = asset.Generation
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		fingerprinted, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fingerprinted).ToNot(BeZero())
+		g.Expect(fingerprinted.Reason).To(Equal(v1beta1.AssetMutationFailed))
+		g.Expect(fingerprinted.WebhooksFingerprint).ToNot(BeEmpty())
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, *fingerprinted)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+}
+
+func TestAssetHandler_Handle_Retry(t *testing.T) {
+	t.Run("Backoff", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		retryPolicy := asset.RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Hour}
+		now := time.Now()
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetPullingFailed
+		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.RetryCount = 2
+
+		handler, mocks := newHandlerWithRetryPolicy(relistInterval, retryPolicy)
+		defer mocks.AssertExpectations(t)
+
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("test-error")).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -257,145 +1536,142 @@ func TestAssetHandler_Handle_OnReady(t *testing.T) {
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetRemoteContentVerificationError))
+		g.Expect(status.RetryCount).To(Equal(3))
+		g.Expect(status.NextRetryTime).ToNot(BeNil())
+		g.Expect(status.NextRetryTime.Time).To(BeTemporally("~", now.Add(4*time.Second), time.Second))
 	})
-}
 
-func TestAssetHandler_Handle_OnPending(t *testing.T) {
-	t.Run("WithWebhooks", func(t *testing.T) {
+	t.Run("MaxInterval", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
+		retryPolicy := asset.RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Hour}
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Spec.RetryPolicy = &v1beta1.AssetRetryPolicy{MaxInterval: &v1.Duration{Duration: 10 * time.Second}}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetPullingFailed
 		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.RetryCount = 20
 
-		handler, mocks := newHandler(relistInterval)
+		handler, mocks := newHandlerWithRetryPolicy(relistInterval, retryPolicy)
 		defer mocks.AssertExpectations(t)
 
 		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("test-error")).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.RetryCount).To(Equal(21))
+		g.Expect(status.NextRetryTime.Time).To(BeTemporally("~", now.Add(10*time.Second), time.Second))
 	})
 
-	t.Run("WithoutWebhooks", func(t *testing.T) {
+	t.Run("InitialInterval", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
+		retryPolicy := asset.RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Hour}
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Spec.RetryPolicy = &v1beta1.AssetRetryPolicy{InitialInterval: &v1.Duration{Duration: 2 * time.Second}}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetPullingFailed
 		asset.Status.ObservedGeneration = asset.Generation
-		asset.Spec.Source.ValidationWebhookService = nil
-		asset.Spec.Source.MutationWebhookService = nil
-		asset.Spec.Source.MetadataWebhookService = nil
+		asset.Status.RetryCount = 2
 
-		handler, mocks := newHandler(relistInterval)
+		handler, mocks := newHandlerWithRetryPolicy(relistInterval, retryPolicy)
 		defer mocks.AssertExpectations(t)
 
 		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("test-error")).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(status.RetryCount).To(Equal(3))
+		g.Expect(status.NextRetryTime.Time).To(BeTemporally("~", now.Add(8*time.Second), time.Second))
 	})
 
-	t.Run("LoadError", func(t *testing.T) {
+	t.Run("NotDue", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
+		nextRetryTime := v1.NewTime(now.Add(time.Minute))
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetPullingFailed
 		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.RetryCount = 1
+		asset.Status.NextRetryTime = &nextRetryTime
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("nope")).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetPullingFailed))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
 	})
 
-	t.Run("MutationFailed", func(t *testing.T) {
+	t.Run("MaxAttempts", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Spec.RetryPolicy = &v1beta1.AssetRetryPolicy{MaxAttempts: 3}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetPullingFailed
 		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.RetryCount = 3
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationFailed))
+		g.Expect(status).To(BeZero())
 	})
 
-	t.Run("MutationError", func(t *testing.T) {
+	t.Run("MaxAttemptsReached", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
+		retryPolicy := asset.RetryPolicy{InitialInterval: time.Second, MaxInterval: time.Hour}
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Spec.RetryPolicy = &v1beta1.AssetRetryPolicy{MaxAttempts: 3}
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetPullingFailed
 		asset.Status.ObservedGeneration = asset.Generation
+		asset.Status.RetryCount = 2
 
-		handler, mocks := newHandler(relistInterval)
+		handler, mocks := newHandlerWithRetryPolicy(relistInterval, retryPolicy)
 		defer mocks.AssertExpectations(t)
 
 		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, errors.New("test-error")).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
@@ -403,138 +1679,147 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 		// Then
 		g.Expect(err).To(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMutationError))
+		g.Expect(status.RetryCount).To(Equal(3))
+		g.Expect(status.NextRetryTime).To(BeNil())
+		g.Expect(mocks.recorder.Events).To(Receive(ContainSubstring(string(v1beta1.AssetPullingFailed))))
+		g.Expect(mocks.recorder.Events).To(Receive(ContainSubstring(string(v1beta1.AssetRetriesExhausted))))
 	})
 
-	t.Run("MetadataExtractionFailed", func(t *testing.T) {
+	t.Run("WebhooksChanged", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
+		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetValidationFailed
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
+		fingerprinted, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fingerprinted).ToNot(BeZero())
+
+		mocks.serviceVersions[""] = "2"
 		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
 		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
 		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
 		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, errors.New("nope")).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
 
 		// When
-		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, *fingerprinted)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetMetadataExtractionFailed))
+		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
+		g.Expect(status.RetryCount).To(BeZero())
+		g.Expect(status.WebhooksFingerprint).To(BeEmpty())
 	})
+}
 
-	t.Run("ValidationError", func(t *testing.T) {
+func TestAssetHandler_Handle_Suspended(t *testing.T) {
+	t.Run("Suspend", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Suspend = true
+		asset.ObjectMeta.Generation = int64(2)
+		asset.Status.ObservedGeneration = int64(1)
+		asset.Status.Phase = v1beta1.AssetFailed
+		asset.Status.Reason = v1beta1.AssetPullingFailed
+		asset.Status.RetryCount = 2
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, errors.New("nope")).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
 		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationError))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetSuspended))
+		g.Expect(status.ObservedGeneration).To(Equal(int64(1)))
+		g.Expect(status.RetryCount).To(Equal(2))
 	})
 
-	t.Run("ValidationFailed", func(t *testing.T) {
+	t.Run("AlreadySuspended", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Suspend = true
+		asset.Status.Phase = v1beta1.AssetReady
+		asset.Status.Reason = v1beta1.AssetSuspended
+		asset.Status.LastHeartbeatTime = v1.NewTime(now.Add(-time.Hour))
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetValidationFailed))
+		g.Expect(status).To(BeZero())
 	})
+}
 
-	t.Run("UploadError", func(t *testing.T) {
+func TestAssetHandler_Handle_Expiration(t *testing.T) {
+	t.Run("Scheduled", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		asset.CreationTimestamp = v1.NewTime(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+		asset.Spec.Suspend = true
+		asset.Spec.TTL = &v1.Duration{Duration: time.Hour}
+		asset.Status.Phase = v1beta1.AssetReady
+		asset.Status.Reason = v1beta1.AssetSuspended
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
-		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(errors.New("nope")).Once()
-		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
-		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
-
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
+		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploadFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.AssetSuspended))
+		g.Expect(status.RetryCount).To(BeZero())
+		g.Expect(status.ExpirationTime).ToNot(BeNil())
+		g.Expect(status.ExpirationTime.Time).To(BeTemporally("==", asset.CreationTimestamp.Add(time.Hour)))
 	})
 
-	t.Run("BucketNotReady", func(t *testing.T) {
+	t.Run("AlreadyScheduled", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "notReady", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		expiresAt := v1.NewTime(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Spec.Suspend = true
+		asset.Spec.ExpiresAt = &expiresAt
+		asset.Status.Phase = v1beta1.AssetReady
+		asset.Status.Reason = v1beta1.AssetSuspended
+		asset.Status.ExpirationTime = expiresAt.DeepCopy()
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
@@ -544,20 +1829,22 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetPending))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketNotReady))
+		g.Expect(status).To(BeZero())
 	})
+}
 
-	t.Run("BucketStatusError", func(t *testing.T) {
+func TestAssetHandler_Handle_Conditions(t *testing.T) {
+	t.Run("OnAdd", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "error", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.ObservedGeneration = asset.Generation
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
+		asset.Status.Conditions = []v1beta1.AssetCondition{
+			{Type: v1beta1.AssetConditionSourcePulled, Status: v1.ConditionTrue, Reason: v1beta1.AssetPulled},
+			{Type: v1beta1.AssetConditionReady, Status: v1.ConditionTrue, Reason: v1beta1.AssetUploaded},
+		}
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
@@ -566,67 +1853,84 @@ func TestAssetHandler_Handle_OnPending(t *testing.T) {
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
-		g.Expect(err).To(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetFailed))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetBucketError))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditionsOf(status)).To(Equal(map[v1beta1.AssetConditionType]v1beta1.AssetReason{
+			v1beta1.AssetConditionReady: v1beta1.AssetScheduled,
+		}))
+		g.Expect(status.Conditions[0].Status).To(Equal(v1.ConditionFalse))
+		g.Expect(status.Conditions[0].ObservedGeneration).To(Equal(asset.Generation))
 	})
 
-	t.Run("OnBucketNotReadyBeforeTime", func(t *testing.T) {
+	t.Run("Uploaded", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
-		asset := testData("test-asset", "error", "https://localhost/test.md")
+		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
 		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketNotReady
-		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.Now()
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil, nil).Once()
+
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(conditionsOf(status)).To(Equal(map[v1beta1.AssetConditionType]v1beta1.AssetReason{
+			v1beta1.AssetConditionSourcePulled:      v1beta1.AssetPulled,
+			v1beta1.AssetConditionMutated:           v1beta1.AssetMutated,
+			v1beta1.AssetConditionValidated:         v1beta1.AssetValidated,
+			v1beta1.AssetConditionMetadataExtracted: v1beta1.AssetMetadataExtracted,
+			v1beta1.AssetConditionUploaded:          v1beta1.AssetUploaded,
+			v1beta1.AssetConditionReady:             v1beta1.AssetUploaded,
+		}))
+		for _, condition := range status.Conditions {
+			g.Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		}
 	})
-}
 
-func TestAssetHandler_Handle_OnFailed(t *testing.T) {
-	t.Run("ShouldHandle", func(t *testing.T) {
+	t.Run("WithoutWebhooks", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetBucketError
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
 		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
-		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string")).Return(nil).Once()
+		mocks.store.On("PutObjects", ctx, remoteBucketName, asset.Name, "/tmp", mock.AnythingOfType("[]string"), (*store.Encryption)(nil)).Return(nil, nil).Once()
 		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
 		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
-		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: true}, nil).Once()
-		mocks.metadataExtractor.On("Extract", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MetadataWebhookService).Return(nil, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).ToNot(BeZero())
-		g.Expect(status.Phase).To(Equal(v1beta1.AssetReady))
-		g.Expect(status.Reason).To(Equal(v1beta1.AssetUploaded))
+		g.Expect(conditionsOf(status)).To(Equal(map[v1beta1.AssetConditionType]v1beta1.AssetReason{
+			v1beta1.AssetConditionSourcePulled: v1beta1.AssetPulled,
+			v1beta1.AssetConditionUploaded:     v1beta1.AssetUploaded,
+			v1beta1.AssetConditionReady:        v1beta1.AssetUploaded,
+		}))
 	})
 
 	t.Run("ValidationFailed", func(t *testing.T) {
@@ -636,41 +1940,68 @@ func TestAssetHandler_Handle_OnFailed(t *testing.T) {
 		relistInterval := time.Minute
 		now := time.Now()
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetValidationFailed
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetPending
 		asset.Status.ObservedGeneration = asset.Generation
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
 
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(nil, nil).Once()
+		mocks.loader.On("Load", asset.Spec.Source.URL, asset.Name, asset.Spec.Source.Mode, asset.Spec.Source.Filter).Return("/tmp", nil, nil).Once()
+		mocks.loader.On("Clean", "/tmp").Return(nil).Once()
+		mocks.mutator.On("Mutate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.MutationWebhookService).Return(engine.Result{Success: true}, nil).Once()
+		mocks.validator.On("Validate", ctx, "/tmp", mock.AnythingOfType("[]string"), asset.Spec.Source.ValidationWebhookService).Return(engine.Result{Success: false}, nil).Once()
+
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(conditionsOf(status)).To(Equal(map[v1beta1.AssetConditionType]v1beta1.AssetReason{
+			v1beta1.AssetConditionSourcePulled: v1beta1.AssetPulled,
+			v1beta1.AssetConditionMutated:      v1beta1.AssetMutated,
+			v1beta1.AssetConditionValidated:    v1beta1.AssetValidationFailed,
+			v1beta1.AssetConditionReady:        v1beta1.AssetValidationFailed,
+		}))
+		g.Expect(status.Conditions[2].Status).To(Equal(v1.ConditionFalse))
+		g.Expect(status.Conditions[3].Status).To(Equal(v1.ConditionFalse))
 	})
 
-	t.Run("MutationFailed", func(t *testing.T) {
+	t.Run("KeepsTransitionTime", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
 		ctx := context.TODO()
 		relistInterval := time.Minute
 		now := time.Now()
+		transitionTime := v1.NewTime(now.Add(-time.Hour))
 		asset := testData("test-asset", "test-bucket", "https://localhost/test.md")
-		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetFailed
-		asset.Status.CommonAssetStatus.Reason = v1beta1.AssetMutationFailed
-		asset.Status.ObservedGeneration = asset.Generation
+		asset.Spec.Source.ValidationWebhookService = nil
+		asset.Spec.Source.MutationWebhookService = nil
+		asset.Spec.Source.MetadataWebhookService = nil
+		asset.Status.CommonAssetStatus.Phase = v1beta1.AssetReady
+		asset.Status.CommonAssetStatus.LastHeartbeatTime = v1.NewTime(now.Add(-2 * relistInterval))
+		asset.Status.CommonAssetStatus.ObservedGeneration = asset.Generation
+		asset.Status.Conditions = []v1beta1.AssetCondition{
+			{Type: v1beta1.AssetConditionSourcePulled, Status: v1.ConditionTrue, Reason: v1beta1.AssetPulled, LastTransitionTime: transitionTime},
+			{Type: v1beta1.AssetConditionUploaded, Status: v1.ConditionTrue, Reason: v1beta1.AssetUploaded, LastTransitionTime: transitionTime},
+			{Type: v1beta1.AssetConditionReady, Status: v1.ConditionTrue, Reason: v1beta1.AssetUploaded, LastTransitionTime: transitionTime},
+		}
 
 		handler, mocks := newHandler(relistInterval)
 		defer mocks.AssertExpectations(t)
+		mocks.store.On("VerifyObjects", ctx, remoteBucketName, asset.Name, mock.AnythingOfType("map[string]store.ObjectDigest"), (*store.Encryption)(nil)).Return(&store.Verification{Modified: []string{"test.md"}}, nil).Once()
 
 		// When
 		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
 
 		// Then
 		g.Expect(err).ToNot(HaveOccurred())
-		g.Expect(status).To(BeZero())
+		g.Expect(status.Conditions).To(HaveLen(3))
+		g.Expect(status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+		g.Expect(status.Conditions[1].Status).To(Equal(v1.ConditionFalse))
+		g.Expect(status.Conditions[1].Reason).To(Equal(v1beta1.AssetContentModified))
+		g.Expect(status.Conditions[1].LastTransitionTime).To(Equal(v1.NewTime(now)))
+		g.Expect(status.Conditions[2].Status).To(Equal(v1.ConditionFalse))
 	})
 }
 
@@ -697,6 +2028,51 @@ func TestAssetHandler_Handle_OnDelete(t *testing.T) {
 		g.Expect(status).To(BeZero())
 	})
 
+	t.Run("RetainedBucket", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "retained-bucket", "https://localhost/test.md")
+		nowMeta := v1.Now()
+		asset.ObjectMeta.DeletionTimestamp = &nowMeta
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("OrphanedBucket", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		asset := testData("test-asset", "orphaned-bucket", "https://localhost/test.md")
+		nowMeta := v1.Now()
+		asset.ObjectMeta.DeletionTimestamp = &nowMeta
+		files := []string{"test/a.txt"}
+
+		handler, mocks := newHandler(relistInterval)
+		defer mocks.AssertExpectations(t)
+		mocks.store.On("ListObjects", ctx, remoteBucketName, asset.Name).Return(files, nil).Once()
+		mocks.store.On("DeleteObjects", ctx, remoteBucketName, asset.Name).Return(nil).Once()
+
+		// When
+		status, err := handler.Do(ctx, now, asset, asset.Spec.CommonAssetSpec, asset.Status.CommonAssetStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
 	t.Run("MultipleFiles", func(t *testing.T) {
 		// Given
 		g := NewGomegaWithT(t)
@@ -816,6 +2192,48 @@ func bucketStatusFinder(ctx context.Context, namespace, name string) (*v1beta1.C
 		return nil, false, nil
 	case strings.Contains(name, "error"):
 		return nil, false, errors.New("test-error")
+	case strings.Contains(name, "foreign-key"):
+		return &v1beta1.CommonBucketStatus{
+			Phase:      v1beta1.BucketReady,
+			URL:        "http://test-url.com/bucket-name",
+			RemoteName: remoteBucketName,
+			Encryption: &v1beta1.BucketEncryption{
+				Mode: v1beta1.BucketEncryptionSSEC,
+				KeySecretRef: &v1beta1.SecretKeyRef{
+					Name:      name,
+					Namespace: "other-namespace",
+					Key:       "key",
+				},
+			},
+		}, true, nil
+	case strings.Contains(name, "encrypted"):
+		return &v1beta1.CommonBucketStatus{
+			Phase:      v1beta1.BucketReady,
+			URL:        "http://test-url.com/bucket-name",
+			RemoteName: remoteBucketName,
+			Encryption: &v1beta1.BucketEncryption{
+				Mode: v1beta1.BucketEncryptionSSEC,
+				KeySecretRef: &v1beta1.SecretKeyRef{
+					Name:      name,
+					Namespace: "test-namespace",
+					Key:       "key",
+				},
+			},
+		}, true, nil
+	case strings.Contains(name, "retained"):
+		return &v1beta1.CommonBucketStatus{
+			Phase:          v1beta1.BucketReady,
+			URL:            "http://test-url.com/bucket-name",
+			RemoteName:     remoteBucketName,
+			DeletionPolicy: v1beta1.BucketDeletionPolicyRetain,
+		}, true, nil
+	case strings.Contains(name, "orphaned"):
+		return &v1beta1.CommonBucketStatus{
+			Phase:          v1beta1.BucketReady,
+			URL:            "http://test-url.com/bucket-name",
+			RemoteName:     remoteBucketName,
+			DeletionPolicy: v1beta1.BucketDeletionPolicyOrphan,
+		}, true, nil
 	default:
 		return &v1beta1.CommonBucketStatus{
 			Phase:      v1beta1.BucketReady,
@@ -825,12 +2243,24 @@ func bucketStatusFinder(ctx context.Context, namespace, name string) (*v1beta1.C
 	}
 }
 
+func secretKeyFinder(ctx context.Context, namespace, name, key string) ([]byte, error) {
+	if strings.Contains(name, "missing") {
+		return nil, errors.New("test-error")
+	}
+
+	return []byte(encryptionKey), nil
+}
+
 type mocks struct {
 	store             *storeMock.Store
 	loader            *loaderMock.Loader
 	validator         *engineMock.Validator
 	mutator           *engineMock.Mutator
 	metadataExtractor *engineMock.MetadataExtractor
+	notifier          *engineMock.Notifier
+	recorder          *record.FakeRecorder
+	serviceVersions   map[string]string
+	configMapVersions map[string]string
 }
 
 func (m *mocks) AssertExpectations(t *testing.T) {
@@ -839,24 +2269,64 @@ func (m *mocks) AssertExpectations(t *testing.T) {
 	m.validator.AssertExpectations(t)
 	m.mutator.AssertExpectations(t)
 	m.metadataExtractor.AssertExpectations(t)
+	m.notifier.AssertExpectations(t)
 }
 
 func newHandler(relistInterval time.Duration) (asset.Handler, mocks) {
+	return newHandlerWithRetryPolicy(relistInterval, asset.RetryPolicy{})
+}
+
+func newHandlerWithRetryPolicy(relistInterval time.Duration, retryPolicy asset.RetryPolicy) (asset.Handler, mocks) {
 	mocks := mocks{
 		store:             new(storeMock.Store),
 		loader:            new(loaderMock.Loader),
 		validator:         new(engineMock.Validator),
 		mutator:           new(engineMock.Mutator),
 		metadataExtractor: new(engineMock.MetadataExtractor),
+		notifier:          new(engineMock.Notifier),
+		recorder:          record.NewFakeRecorder(20),
+		serviceVersions:   map[string]string{},
+		configMapVersions: map[string]string{},
+	}
+	serviceVersionFinder := func(ctx context.Context, namespace, name string) (string, error) {
+		return mocks.serviceVersions[name], nil
+	}
+	configMapVersionFinder := func(ctx context.Context, namespace, name string) (string, error) {
+		return mocks.configMapVersions[name], nil
 	}
 
-	handler := asset.New(log, fakeRecorder(), mocks.store, mocks.loader, bucketStatusFinder, mocks.validator, mocks.mutator, mocks.metadataExtractor, relistInterval)
+	handler := asset.New(log, mocks.recorder, mocks.store, mocks.loader, bucketStatusFinder, secretKeyFinder, serviceVersionFinder, configMapVersionFinder, mocks.validator, mocks.mutator, mocks.metadataExtractor, mocks.notifier, relistInterval, retryPolicy)
 
 	return handler, mocks
 }
 
-func fakeRecorder() record.EventRecorder {
-	return record.NewFakeRecorder(20)
+func reasonsTotal(g *GomegaWithT, reason v1beta1.AssetReason) float64 {
+	families, err := metrics.Registry.Gather()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() != "rafter_controller_manager_asset_reasons_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "reason" && label.GetValue() == reason.String() {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func conditionsOf(status *v1beta1.CommonAssetStatus) map[v1beta1.AssetConditionType]v1beta1.AssetReason {
+	result := make(map[v1beta1.AssetConditionType]v1beta1.AssetReason)
+	for _, condition := range status.Conditions {
+		result[condition.Type] = condition.Reason
+	}
+
+	return result
 }
 
 func testData(assetName, bucketName, url string) *v1beta1.Asset {
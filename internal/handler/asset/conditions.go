@@ -0,0 +1,151 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type stage struct {
+	conditionType v1beta1.AssetConditionType
+	enabled       bool
+	succeeded     v1beta1.AssetReason
+	failed        []v1beta1.AssetReason
+}
+
+var conditionsOrder = []v1beta1.AssetConditionType{
+	v1beta1.AssetConditionSourcePulled,
+	v1beta1.AssetConditionMutated,
+	v1beta1.AssetConditionValidated,
+	v1beta1.AssetConditionMetadataExtracted,
+	v1beta1.AssetConditionUploaded,
+	v1beta1.AssetConditionReady,
+}
+
+func (*assetHandler) getStages(spec v1beta1.CommonAssetSpec) []stage {
+	return []stage{
+		{
+			conditionType: v1beta1.AssetConditionSourcePulled,
+			enabled:       true,
+			succeeded:     v1beta1.AssetPulled,
+			failed:        []v1beta1.AssetReason{v1beta1.AssetPullingFailed},
+		},
+		{
+			conditionType: v1beta1.AssetConditionMutated,
+			enabled:       len(spec.Source.MutationWebhookService) > 0,
+			succeeded:     v1beta1.AssetMutated,
+			failed:        []v1beta1.AssetReason{v1beta1.AssetMutationFailed, v1beta1.AssetMutationError},
+		},
+		{
+			conditionType: v1beta1.AssetConditionValidated,
+			enabled:       len(spec.Source.ValidationWebhookService) > 0,
+			succeeded:     v1beta1.AssetValidated,
+			failed:        []v1beta1.AssetReason{v1beta1.AssetValidationFailed, v1beta1.AssetValidationError},
+		},
+		{
+			conditionType: v1beta1.AssetConditionMetadataExtracted,
+			enabled:       len(spec.Source.MetadataWebhookService) > 0,
+			succeeded:     v1beta1.AssetMetadataExtracted,
+			failed:        []v1beta1.AssetReason{v1beta1.AssetMetadataExtractionFailed},
+		},
+		{
+			conditionType: v1beta1.AssetConditionUploaded,
+			enabled:       true,
+			succeeded:     v1beta1.AssetUploaded,
+			failed: []v1beta1.AssetReason{
+				v1beta1.AssetCleanupError,
+				v1beta1.AssetUploadFailed,
+				v1beta1.AssetMissingContent,
+				v1beta1.AssetContentModified,
+				v1beta1.AssetRemoteContentVerificationError,
+			},
+		},
+	}
+}
+
+// setConditions fills in the conditions of the new status. Stages which were not reached for the current generation
+// or since the last resync and stages without configured webhooks have no condition.
+func (h *assetHandler) setConditions(spec v1beta1.CommonAssetSpec, current v1beta1.CommonAssetStatus, status *v1beta1.CommonAssetStatus, now time.Time) {
+	conditions := make(map[v1beta1.AssetConditionType]v1beta1.AssetCondition)
+	for _, condition := range current.Conditions {
+		conditions[condition.Type] = condition
+	}
+	if current.ObservedGeneration != status.ObservedGeneration || status.Reason == v1beta1.AssetScheduled {
+		conditions = make(map[v1beta1.AssetConditionType]v1beta1.AssetCondition)
+	}
+
+	stages := h.getStages(spec)
+	failedIndex := h.findFailedStage(stages, status.Reason)
+	switch {
+	case status.Reason == v1beta1.AssetUploaded:
+		for _, stage := range stages {
+			if !stage.enabled {
+				delete(conditions, stage.conditionType)
+				continue
+			}
+			h.setCondition(conditions, now, status.ObservedGeneration, stage.conditionType, v1.ConditionTrue, stage.succeeded, stage.succeeded.Message())
+		}
+	case status.Reason == v1beta1.AssetDryRunSucceeded:
+		for _, stage := range stages {
+			if !stage.enabled || stage.conditionType == v1beta1.AssetConditionUploaded {
+				delete(conditions, stage.conditionType)
+				continue
+			}
+			h.setCondition(conditions, now, status.ObservedGeneration, stage.conditionType, v1.ConditionTrue, stage.succeeded, stage.succeeded.Message())
+		}
+	case failedIndex >= 0:
+		for i, stage := range stages {
+			switch {
+			case !stage.enabled || i > failedIndex:
+				delete(conditions, stage.conditionType)
+			case i < failedIndex:
+				h.setCondition(conditions, now, status.ObservedGeneration, stage.conditionType, v1.ConditionTrue, stage.succeeded, stage.succeeded.Message())
+			default:
+				h.setCondition(conditions, now, status.ObservedGeneration, stage.conditionType, v1.ConditionFalse, status.Reason, status.Message)
+			}
+		}
+	}
+
+	readyStatus := v1.ConditionFalse
+	if status.Phase == v1beta1.AssetReady {
+		readyStatus = v1.ConditionTrue
+	}
+	h.setCondition(conditions, now, status.ObservedGeneration, v1beta1.AssetConditionReady, readyStatus, status.Reason, status.Message)
+
+	status.Conditions = make([]v1beta1.AssetCondition, 0, len(conditions))
+	for _, conditionType := range conditionsOrder {
+		if condition, ok := conditions[conditionType]; ok {
+			status.Conditions = append(status.Conditions, condition)
+		}
+	}
+}
+
+func (*assetHandler) findFailedStage(stages []stage, reason v1beta1.AssetReason) int {
+	for i, stage := range stages {
+		for _, failed := range stage.failed {
+			if failed == reason {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func (*assetHandler) setCondition(conditions map[v1beta1.AssetConditionType]v1beta1.AssetCondition, now time.Time, generation int64, conditionType v1beta1.AssetConditionType, status v1.ConditionStatus, reason v1beta1.AssetReason, message string) {
+	condition := v1beta1.AssetCondition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: generation,
+		LastTransitionTime: v1.NewTime(now),
+		Reason:             reason,
+		Message:            message,
+	}
+
+	if previous, ok := conditions[conditionType]; ok && previous.Status == status {
+		condition.LastTransitionTime = previous.LastTransitionTime
+	}
+
+	conditions[conditionType] = condition
+}
@@ -0,0 +1,31 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/kyma-project/rafter/internal/expiry"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+)
+
+// setExpiration records the expiration time in the status and announces it with an event whenever it changes
+func (h *assetHandler) setExpiration(object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus, newStatus *v1beta1.CommonAssetStatus) *v1beta1.CommonAssetStatus {
+	if object.GetDeletionTimestamp() != nil {
+		return newStatus
+	}
+
+	expirationTime := expiry.Time(object.GetCreationTimestamp(), spec.TTL, spec.ExpiresAt)
+	isChanged := !expirationTime.Equal(status.ExpirationTime)
+	if newStatus == nil {
+		if !isChanged {
+			return nil
+		}
+		newStatus = status.DeepCopy()
+	}
+
+	newStatus.ExpirationTime = expirationTime
+	if isChanged && expirationTime != nil {
+		h.recordNormalEventf(object, v1beta1.AssetExpirationScheduled, expirationTime.Format(time.RFC3339))
+	}
+
+	return newStatus
+}
@@ -0,0 +1,52 @@
+package asset
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	stageLoad               = "load"
+	stageMutation           = "mutation"
+	stageValidation         = "validation"
+	stageMetadataExtraction = "metadata_extraction"
+	stageUpload             = "upload"
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+	outcomeError   = "error"
+)
+
+var (
+	stageDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "rafter_controller_manager_asset_stage_duration_seconds",
+		Help: "Asset processing stage duration distribution",
+	}, []string{"stage", "outcome"})
+	reasonsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rafter_controller_manager_asset_reasons_total",
+		Help: "Number of asset status changes to the given reason",
+	}, []string{"reason"})
+	uploadedBytesCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "rafter_controller_manager_asset_uploaded_bytes_total",
+		Help: "Number of bytes uploaded to the storage",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(stageDurationHistogram, reasonsCounter, uploadedBytesCounter)
+}
+
+// observeStage records the duration of a processing stage, where a stage that hasn't succeeded without an error is a failure
+func observeStage(stage string, start time.Time, err error, success bool) {
+	outcome := outcomeSuccess
+	switch {
+	case err != nil:
+		outcome = outcomeError
+	case !success:
+		outcome = outcomeFailure
+	}
+
+	stageDurationHistogram.WithLabelValues(stage, outcome).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,112 @@
+package asset
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/assethook/api/v1alpha1"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultDeletedNotificationAttempts limits retries of deletion notifications when the retry policy has no limit,
+// so that a broken webhook doesn't block the deletion of the asset forever
+const defaultDeletedNotificationAttempts = 5
+
+// notify informs notification webhooks about the changed asset content. A failed notification is returned as pending,
+// it is retried by next reconciliations and doesn't affect the asset phase.
+func (h *assetHandler) notify(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, event v1beta1.AssetNotificationEvent, baseUrl string, files []v1beta1.AssetFile) *v1beta1.AssetPendingNotification {
+	if spec.DryRun || len(spec.Source.NotificationWebhookService) == 0 {
+		return nil
+	}
+
+	h.logInfof("Sending %s notification", event)
+	notification := v1alpha1.NotificationRequest{
+		Event:      v1alpha1.NotificationEvent(event),
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+		Generation: object.GetGeneration(),
+		BaseURL:    baseUrl,
+		Files:      h.toNotificationFiles(files),
+	}
+	if err := h.notifier.Notify(ctx, notification, spec.Source.NotificationWebhookService); err != nil {
+		h.recordWarningEventf(object, v1beta1.AssetNotificationFailed, err.Error())
+		return &v1beta1.AssetPendingNotification{
+			Event:   event,
+			BaseURL: baseUrl,
+			Message: err.Error(),
+		}
+	}
+	h.logInfof("Notification sent")
+
+	return nil
+}
+
+func (*assetHandler) isOnNotificationPending(status v1beta1.CommonAssetStatus, now time.Time) bool {
+	return status.PendingNotification != nil &&
+		status.PendingNotification.Event == v1beta1.AssetNotificationUploaded &&
+		status.Phase == v1beta1.AssetReady &&
+		!now.Before(status.PendingNotification.NextAttemptTime.Time)
+}
+
+// onNotificationPending sends the pending notification again. Notification webhooks which accepted it before receive it once more.
+func (h *assetHandler) onNotificationPending(ctx context.Context, object MetaAccessor, spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus) *v1beta1.CommonAssetStatus {
+	pending := status.PendingNotification
+	newStatus := status.DeepCopy()
+	newStatus.PendingNotification = nil
+
+	maxAttempts := h.getRetryPolicy(spec).MaxAttempts
+	if maxAttempts == 0 && pending.Event == v1beta1.AssetNotificationDeleted {
+		maxAttempts = defaultDeletedNotificationAttempts
+	}
+	if maxAttempts > 0 && pending.Attempts >= maxAttempts {
+		h.recordWarningEventf(object, v1beta1.AssetNotificationAbandoned, pending.Event, pending.Attempts)
+		return newStatus
+	}
+
+	var files []v1beta1.AssetFile
+	if pending.Event == v1beta1.AssetNotificationUploaded {
+		files = status.AssetRef.Files
+	}
+	newStatus.PendingNotification = h.notify(ctx, object, spec, pending.Event, pending.BaseURL, files)
+
+	return newStatus
+}
+
+// setNotificationRetry counts failed attempts of the same notification and schedules the next one
+func (h *assetHandler) setNotificationRetry(spec v1beta1.CommonAssetSpec, current v1beta1.CommonAssetStatus, status *v1beta1.CommonAssetStatus, now time.Time) {
+	pending := status.PendingNotification
+	if pending == nil || pending.Attempts > 0 {
+		return
+	}
+
+	pending.Attempts = 1
+	if current.PendingNotification != nil && current.PendingNotification.Event == pending.Event {
+		pending.Attempts = current.PendingNotification.Attempts + 1
+	}
+	pending.NextAttemptTime = v1.NewTime(now.Add(h.getBackoff(spec, pending.Attempts)))
+}
+
+func (*assetHandler) toNotificationFiles(files []v1beta1.AssetFile) []v1alpha1.NotificationFile {
+	if len(files) == 0 {
+		return nil
+	}
+
+	result := make([]v1alpha1.NotificationFile, 0, len(files))
+	for _, file := range files {
+		notificationFile := v1alpha1.NotificationFile{
+			Name:   file.Name,
+			URL:    file.URL,
+			Sha256: file.Sha256,
+			Size:   file.Size,
+		}
+		if file.Metadata != nil {
+			metadata := json.RawMessage(file.Metadata.Raw)
+			notificationFile.Metadata = &metadata
+		}
+		result = append(result, notificationFile)
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package asset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RetryPolicy defines the default backoff of failed assets, MaxAttempts equal to 0 means no limit
+type RetryPolicy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxAttempts     int
+}
+
+func (h *assetHandler) getRetryPolicy(spec v1beta1.CommonAssetSpec) RetryPolicy {
+	policy := h.retryPolicy
+	if spec.RetryPolicy == nil {
+		return policy
+	}
+
+	if spec.RetryPolicy.MaxAttempts > 0 {
+		policy.MaxAttempts = spec.RetryPolicy.MaxAttempts
+	}
+	if spec.RetryPolicy.InitialInterval != nil {
+		policy.InitialInterval = spec.RetryPolicy.InitialInterval.Duration
+	}
+	if spec.RetryPolicy.MaxInterval != nil {
+		policy.MaxInterval = spec.RetryPolicy.MaxInterval.Duration
+	}
+
+	return policy
+}
+
+func (h *assetHandler) isRetryAllowed(spec v1beta1.CommonAssetSpec, status v1beta1.CommonAssetStatus, now time.Time) bool {
+	policy := h.getRetryPolicy(spec)
+	if policy.MaxAttempts > 0 && status.RetryCount >= policy.MaxAttempts {
+		return false
+	}
+
+	return status.NextRetryTime == nil || !now.Before(status.NextRetryTime.Time)
+}
+
+// setRetry counts consecutive failures of the same generation and schedules the next attempt. Webhook failures
+// are retried only when the webhooks configuration changes.
+func (h *assetHandler) setRetry(object MetaAccessor, spec v1beta1.CommonAssetSpec, current v1beta1.CommonAssetStatus, status *v1beta1.CommonAssetStatus, now time.Time) {
+	if status.Phase != v1beta1.AssetFailed || status.Reason == v1beta1.AssetSuspended || h.isWebhookFailure(status.Reason) {
+		return
+	}
+
+	status.RetryCount = 1
+	if current.Phase == v1beta1.AssetFailed && current.ObservedGeneration == status.ObservedGeneration {
+		status.RetryCount = current.RetryCount + 1
+	}
+
+	if maxAttempts := h.getRetryPolicy(spec).MaxAttempts; maxAttempts > 0 && status.RetryCount >= maxAttempts {
+		h.recordWarningEventf(object, v1beta1.AssetRetriesExhausted, status.RetryCount)
+		return
+	}
+
+	nextRetryTime := v1.NewTime(now.Add(h.getBackoff(spec, status.RetryCount)))
+	status.NextRetryTime = &nextRetryTime
+}
+
+func (h *assetHandler) getBackoff(spec v1beta1.CommonAssetSpec, retryCount int) time.Duration {
+	policy := h.getRetryPolicy(spec)
+
+	backoff := policy.InitialInterval
+	for i := 1; i < retryCount; i++ {
+		if policy.MaxInterval > 0 && backoff >= policy.MaxInterval {
+			break
+		}
+		backoff *= 2
+	}
+	if policy.MaxInterval > 0 && backoff > policy.MaxInterval {
+		backoff = policy.MaxInterval
+	}
+
+	return backoff
+}
+
+func (*assetHandler) isWebhookFailure(reason v1beta1.AssetReason) bool {
+	return reason == v1beta1.AssetValidationFailed || reason == v1beta1.AssetMutationFailed
+}
+
+// getWebhooksFingerprint identifies the validation and mutation webhooks configuration together with the referenced Services
+func (h *assetHandler) getWebhooksFingerprint(ctx context.Context, spec v1beta1.CommonAssetSpec) (string, error) {
+	services := append(append([]v1beta1.AssetWebhookService{}, spec.Source.ValidationWebhookService...), spec.Source.MutationWebhookService...)
+
+	versions := make([]string, 0, len(services))
+	for _, service := range services {
+		if service.URL != "" {
+			continue
+		}
+		version, err := h.findServiceVersion(ctx, service.Namespace, service.Name)
+		if err != nil {
+			return "", errors.Wrapf(err, "while getting Service %s in namespace %s", service.Name, service.Namespace)
+		}
+		versions = append(versions, fmt.Sprintf("%s/%s:%s", service.Namespace, service.Name, version))
+	}
+	sort.Strings(versions)
+
+	config, err := json.Marshal(services)
+	if err != nil {
+		return "", errors.Wrap(err, "while marshalling webhooks configuration")
+	}
+
+	hash := sha256.New()
+	hash.Write(config)
+	for _, version := range versions {
+		hash.Write([]byte(version))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
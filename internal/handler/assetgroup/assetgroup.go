@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyma-project/rafter/internal/expiry"
 	"github.com/kyma-project/rafter/internal/webhookconfig"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/pkg/errors"
@@ -82,6 +83,38 @@ func (h *assetgroupHandler) Handle(ctx context.Context, instance ObjectMetaAcces
 	h.logInfof("Start common AssetGroup handling")
 	defer h.logInfof("Finish common AssetGroup handling")
 
+	newStatus, err := h.handle(ctx, instance, spec, status)
+	if newStatus != nil && newStatus.LastResyncRequestedAt == "" {
+		newStatus.LastResyncRequestedAt = status.LastResyncRequestedAt
+	}
+
+	return h.setExpiration(instance, spec, status, newStatus), err
+}
+
+// setExpiration records the expiration time in the status and announces it with an event whenever it changes
+func (h *assetgroupHandler) setExpiration(instance ObjectMetaAccessor, spec v1beta1.CommonAssetGroupSpec, status v1beta1.CommonAssetGroupStatus, newStatus *v1beta1.CommonAssetGroupStatus) *v1beta1.CommonAssetGroupStatus {
+	expirationTime := expiry.Time(instance.GetCreationTimestamp(), spec.TTL, spec.ExpiresAt)
+	isChanged := !expirationTime.Equal(status.ExpirationTime)
+	if newStatus == nil {
+		if !isChanged {
+			return nil
+		}
+		newStatus = status.DeepCopy()
+	}
+
+	newStatus.ExpirationTime = expirationTime
+	if isChanged && expirationTime != nil {
+		h.recordNormalEventf(instance, v1beta1.AssetGroupExpirationScheduled, expirationTime.Format(time.RFC3339))
+	}
+
+	return newStatus
+}
+
+func (h *assetgroupHandler) handle(ctx context.Context, instance ObjectMetaAccessor, spec v1beta1.CommonAssetGroupSpec, status v1beta1.CommonAssetGroupStatus) (*v1beta1.CommonAssetGroupStatus, error) {
+	if h.isOnSuspended(spec) {
+		return h.onSuspended(instance, status), nil
+	}
+
 	err := h.validateSpec(spec)
 	if err != nil {
 		h.recordWarningEventf(instance, v1beta1.AssetGroupAssetsSpecValidationFailed, err.Error())
@@ -116,6 +149,8 @@ func (h *assetgroupHandler) Handle(ctx context.Context, instance ObjectMetaAcces
 	switch {
 	case h.isOnChange(commonAssetsMap, spec, bucketName, webhookCfg):
 		return h.onChange(ctx, instance, spec, status, commonAssetsMap, bucketName, webhookCfg)
+	case h.isOnResync(instance, status):
+		return h.onResync(ctx, instance, status, commonAssetsMap)
 	case h.isOnPhaseChange(commonAssetsMap, status):
 		return h.onPhaseChange(instance, status, commonAssetsMap)
 	default:
@@ -174,8 +209,17 @@ func (h *assetgroupHandler) isOnChange(existing map[v1beta1.AssetGroupSourceName
 	return h.shouldCreateAssets(existing, spec) || h.shouldDeleteAssets(existing, spec) || h.shouldUpdateAssets(existing, spec, bucketName, config)
 }
 
+func (h *assetgroupHandler) isOnSuspended(spec v1beta1.CommonAssetGroupSpec) bool {
+	return spec.Suspend
+}
+
+func (h *assetgroupHandler) isOnResync(instance ObjectMetaAccessor, status v1beta1.CommonAssetGroupStatus) bool {
+	resyncRequestedAt := instance.GetAnnotations()[v1beta1.ResyncRequestedAtAnnotation]
+	return resyncRequestedAt != "" && resyncRequestedAt != status.LastResyncRequestedAt
+}
+
 func (h *assetgroupHandler) isOnPhaseChange(existing map[v1beta1.AssetGroupSourceName]CommonAsset, status v1beta1.CommonAssetGroupStatus) bool {
-	return status.Phase != h.calculateAssetPhase(existing)
+	return status.Phase != h.calculateAssetPhase(existing) || status.Reason == v1beta1.AssetGroupSuspended
 }
 
 func (h *assetgroupHandler) shouldCreateAssets(existing map[v1beta1.AssetGroupSourceName]CommonAsset, spec v1beta1.CommonAssetGroupSpec) bool {
@@ -196,7 +240,7 @@ func (h *assetgroupHandler) shouldUpdateAssets(existing map[v1beta1.AssetGroupSo
 		}
 
 		assetWhsMap := config[expectedSpec.Type]
-		expected := h.convertToCommonAssetSpec(*expectedSpec, bucketName, assetWhsMap)
+		expected := h.convertToCommonAssetSpec(*expectedSpec, bucketName, spec.DryRun, assetWhsMap)
 		if !reflect.DeepEqual(expected, existingAsset.Spec) {
 			return true
 		}
@@ -215,6 +259,21 @@ func (h *assetgroupHandler) shouldDeleteAssets(existing map[v1beta1.AssetGroupSo
 	return false
 }
 
+func (h *assetgroupHandler) onSuspended(instance ObjectMetaAccessor, status v1beta1.CommonAssetGroupStatus) *v1beta1.CommonAssetGroupStatus {
+	if status.Reason == v1beta1.AssetGroupSuspended {
+		return nil
+	}
+
+	h.logInfof("AssetGroup reconciliation is suspended")
+	h.recordNormalEventf(instance, v1beta1.AssetGroupSuspended)
+	phase := status.Phase
+	if phase == "" {
+		phase = v1beta1.AssetGroupPending
+	}
+
+	return h.buildStatus(phase, v1beta1.AssetGroupSuspended)
+}
+
 func (h *assetgroupHandler) onPhaseChange(instance ObjectMetaAccessor, status v1beta1.CommonAssetGroupStatus, existing map[v1beta1.AssetGroupSourceName]CommonAsset) (*v1beta1.CommonAssetGroupStatus, error) {
 	phase := h.calculateAssetPhase(existing)
 	h.logInfof("Updating phase to %s", phase)
@@ -224,6 +283,11 @@ func (h *assetgroupHandler) onPhaseChange(instance ObjectMetaAccessor, status v1
 		return h.buildStatus(phase, v1beta1.AssetGroupWaitingForAssets), nil
 	}
 
+	if phase == v1beta1.AssetGroupDryRun {
+		h.recordNormalEventf(instance, v1beta1.AssetGroupAssetsDryRunCompleted)
+		return h.buildStatus(phase, v1beta1.AssetGroupAssetsDryRunCompleted), nil
+	}
+
 	h.recordNormalEventf(instance, v1beta1.AssetGroupAssetsReady)
 	return h.buildStatus(phase, v1beta1.AssetGroupAssetsReady), nil
 }
@@ -245,14 +309,53 @@ func (h *assetgroupHandler) onChange(ctx context.Context, instance ObjectMetaAcc
 	return h.buildStatus(v1beta1.AssetGroupPending, v1beta1.AssetGroupWaitingForAssets), nil
 }
 
+func (h *assetgroupHandler) onResync(ctx context.Context, instance ObjectMetaAccessor, status v1beta1.CommonAssetGroupStatus, existing map[v1beta1.AssetGroupSourceName]CommonAsset) (*v1beta1.CommonAssetGroupStatus, error) {
+	resyncRequestedAt := instance.GetAnnotations()[v1beta1.ResyncRequestedAtAnnotation]
+	if err := h.resyncAssets(ctx, instance, existing, resyncRequestedAt); err != nil {
+		return h.onFailedStatus(h.buildStatus(v1beta1.AssetGroupFailed, v1beta1.AssetGroupAssetsResyncFailed, err.Error()), status), err
+	}
+
+	h.recordNormalEventf(instance, v1beta1.AssetGroupAssetsResyncRequested)
+	newStatus := h.buildStatus(v1beta1.AssetGroupPending, v1beta1.AssetGroupAssetsResyncRequested)
+	newStatus.LastResyncRequestedAt = resyncRequestedAt
+
+	return newStatus, nil
+}
+
+func (h *assetgroupHandler) resyncAssets(ctx context.Context, instance ObjectMetaAccessor, existing map[v1beta1.AssetGroupSourceName]CommonAsset, resyncRequestedAt string) error {
+	for _, existingAsset := range existing {
+		if existingAsset.Annotations[v1beta1.ResyncRequestedAtAnnotation] == resyncRequestedAt {
+			continue
+		}
+
+		h.logInfof("Requesting resync of asset %s", existingAsset.Name)
+		annotations := make(map[string]string, len(existingAsset.Annotations)+1)
+		for key, value := range existingAsset.Annotations {
+			annotations[key] = value
+		}
+		annotations[v1beta1.ResyncRequestedAtAnnotation] = resyncRequestedAt
+		existingAsset.Annotations = annotations
+
+		if err := h.assetSvc.Update(ctx, existingAsset); err != nil {
+			h.recordWarningEventf(instance, v1beta1.AssetGroupAssetUpdateFailed, existingAsset.Name, err.Error())
+			return err
+		}
+		h.logInfof("Asset %s updated", existingAsset.Name)
+		h.recordNormalEventf(instance, v1beta1.AssetGroupAssetUpdated, existingAsset.Name)
+	}
+
+	return nil
+}
+
 func (h *assetgroupHandler) createMissingAssets(ctx context.Context, instance ObjectMetaAccessor, existing map[v1beta1.AssetGroupSourceName]CommonAsset, spec v1beta1.CommonAssetGroupSpec, bucketName string, cfg webhookconfig.AssetWebhookConfigMap) error {
+	dryRun := spec.DryRun
 	for _, spec := range spec.Sources {
 		name := spec.Name
 		if _, exists := existing[name]; exists {
 			continue
 		}
 
-		if err := h.createAsset(ctx, instance, spec, bucketName, cfg[spec.Type]); err != nil {
+		if err := h.createAsset(ctx, instance, spec, bucketName, dryRun, cfg[spec.Type]); err != nil {
 			return err
 		}
 	}
@@ -260,7 +363,7 @@ func (h *assetgroupHandler) createMissingAssets(ctx context.Context, instance Ob
 	return nil
 }
 
-func (h *assetgroupHandler) createAsset(ctx context.Context, instance ObjectMetaAccessor, assetSpec v1beta1.Source, bucketName string, cfg webhookconfig.AssetWebhookConfig) error {
+func (h *assetgroupHandler) createAsset(ctx context.Context, instance ObjectMetaAccessor, assetSpec v1beta1.Source, bucketName string, dryRun bool, cfg webhookconfig.AssetWebhookConfig) error {
 	commonAsset := CommonAsset{
 		ObjectMeta: v1.ObjectMeta{
 			Name:        h.generateFullAssetName(instance.GetName(), assetSpec.Name, assetSpec.Type),
@@ -268,7 +371,7 @@ func (h *assetgroupHandler) createAsset(ctx context.Context, instance ObjectMeta
 			Labels:      h.buildLabels(instance.GetName(), assetSpec.Type),
 			Annotations: h.buildAnnotations(assetSpec.Name),
 		},
-		Spec: h.convertToCommonAssetSpec(assetSpec, bucketName, cfg),
+		Spec: h.convertToCommonAssetSpec(assetSpec, bucketName, dryRun, cfg),
 	}
 
 	h.logInfof("Creating asset %s", commonAsset.Name)
@@ -290,7 +393,7 @@ func (h *assetgroupHandler) updateOutdatedAssets(ctx context.Context, instance O
 		}
 
 		h.logInfof("Updating asset %s", existingAsset.Name)
-		expected := h.convertToCommonAssetSpec(*expectedSpec, bucketName, cfg[expectedSpec.Type])
+		expected := h.convertToCommonAssetSpec(*expectedSpec, bucketName, spec.DryRun, cfg[expectedSpec.Type])
 		if reflect.DeepEqual(expected, existingAsset.Spec) {
 			h.logInfof("Asset %s is up-to-date", existingAsset.Name)
 			continue
@@ -346,21 +449,23 @@ func (h *assetgroupHandler) convertToAssetMap(assets []CommonAsset) map[v1beta1.
 	return result
 }
 
-func (h *assetgroupHandler) convertToCommonAssetSpec(spec v1beta1.Source, bucketName string, cfg webhookconfig.AssetWebhookConfig) v1beta1.CommonAssetSpec {
+func (h *assetgroupHandler) convertToCommonAssetSpec(spec v1beta1.Source, bucketName string, dryRun bool, cfg webhookconfig.AssetWebhookConfig) v1beta1.CommonAssetSpec {
 	return v1beta1.CommonAssetSpec{
 		Source: v1beta1.AssetSource{
-			Mode:                     h.convertToAssetMode(spec.Mode),
-			URL:                      spec.URL,
-			Filter:                   spec.Filter,
-			ValidationWebhookService: convertToAssetWebhookServices(cfg.Validations),
-			MutationWebhookService:   convertToAssetWebhookServices(cfg.Mutations),
-			MetadataWebhookService:   convertToWebhookService(cfg.MetadataExtractors),
+			Mode:                       h.convertToAssetMode(spec.Mode),
+			URL:                        spec.URL,
+			Filter:                     spec.Filter,
+			ValidationWebhookService:   convertToAssetWebhookServices(cfg.Validations),
+			MutationWebhookService:     convertToAssetWebhookServices(cfg.Mutations),
+			MetadataWebhookService:     convertToWebhookService(cfg.MetadataExtractors),
+			NotificationWebhookService: convertToWebhookService(cfg.Notifications),
 		},
 		BucketRef: v1beta1.AssetBucketRef{
 			Name: bucketName,
 		},
 		DisplayName: spec.DisplayName,
 		Parameters:  spec.Parameters,
+		DryRun:      dryRun,
 	}
 }
 
@@ -371,16 +476,28 @@ func convertToWebhookService(services []webhookconfig.WebhookService) []v1beta1.
 	}
 	result := make([]v1beta1.WebhookService, 0, servicesLen)
 	for _, service := range services {
-		result = append(result, v1beta1.WebhookService{
-			Name:      service.Name,
-			Namespace: service.Namespace,
-			Endpoint:  service.Endpoint,
-			Filter:    service.Filter,
-		})
+		result = append(result, convertToWebhook(service))
 	}
 	return result
 }
 
+func convertToWebhook(service webhookconfig.WebhookService) v1beta1.WebhookService {
+	return v1beta1.WebhookService{
+		Name:           service.Name,
+		Namespace:      service.Namespace,
+		Port:           service.Port,
+		Scheme:         service.Scheme,
+		URL:            service.URL,
+		CABundle:       service.CABundle,
+		Authentication: service.Authentication,
+		Timeout:        service.Timeout,
+		FailurePolicy:  service.FailurePolicy,
+		MaxRetries:     service.MaxRetries,
+		Endpoint:       service.Endpoint,
+		Filter:         service.Filter,
+	}
+}
+
 func convertToAssetWebhookServices(services []webhookconfig.AssetWebhookService) []v1beta1.AssetWebhookService {
 	servicesLen := len(services)
 	if servicesLen < 1 {
@@ -389,13 +506,10 @@ func convertToAssetWebhookServices(services []webhookconfig.AssetWebhookService)
 	result := make([]v1beta1.AssetWebhookService, 0, servicesLen)
 	for _, s := range services {
 		result = append(result, v1beta1.AssetWebhookService{
-			WebhookService: v1beta1.WebhookService{
-				Name:      s.Name,
-				Namespace: s.Namespace,
-				Endpoint:  s.Endpoint,
-				Filter:    s.Filter,
-			},
-			Parameters: s.Parameters,
+			WebhookService: convertToWebhook(s.WebhookService),
+			Parameters:     s.Parameters,
+			Batch:          s.Batch,
+			MaxBatchSize:   s.MaxBatchSize,
 		})
 	}
 	return result
@@ -431,13 +545,18 @@ func (h *assetgroupHandler) convertToAssetMode(mode v1beta1.AssetGroupSourceMode
 }
 
 func (h *assetgroupHandler) calculateAssetPhase(existing map[v1beta1.AssetGroupSourceName]CommonAsset) v1beta1.AssetGroupPhase {
+	phase := v1beta1.AssetGroupReady
 	for _, asset := range existing {
-		if asset.Status.Phase != v1beta1.AssetReady {
+		switch asset.Status.Phase {
+		case v1beta1.AssetReady:
+		case v1beta1.AssetDryRun:
+			phase = v1beta1.AssetGroupDryRun
+		default:
 			return v1beta1.AssetGroupPending
 		}
 	}
 
-	return v1beta1.AssetGroupReady
+	return phase
 }
 
 func (h *assetgroupHandler) buildStatus(phase v1beta1.AssetGroupPhase, reason v1beta1.AssetGroupReason, args ...interface{}) *v1beta1.CommonAssetGroupStatus {
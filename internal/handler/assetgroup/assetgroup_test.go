@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/rafter/internal/handler/assetgroup"
 	"github.com/kyma-project/rafter/internal/handler/assetgroup/automock"
@@ -191,6 +192,38 @@ func TestAssetGroupHandler_Handle_AddOrUpdate(t *testing.T) {
 		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupWaitingForAssets))
 	})
 
+	t.Run("CreateDryRun", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "bucketName", sources)
+		testData.Spec.DryRun = true
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(nil, nil).Once()
+		assetSvc.On("Create", ctx, testData, mock.MatchedBy(func(asset assetgroup.CommonAsset) bool {
+			return asset.Spec.DryRun
+		})).Return(nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupPending))
+	})
+
 	t.Run("CreateWithDisplayName", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -699,6 +732,42 @@ func TestAssetGroupHandler_Handle_Status(t *testing.T) {
 		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupAssetsReady))
 	})
 
+	t.Run("AssetsDryRun", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucketName := "test-bucket"
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.Status.Phase = v1beta1.AssetGroupPending
+		source, ok := getSourceByType(sources, sourceName)
+		g.Expect(ok, true)
+		existingAsset := commonAsset(sourceName, assetType, testData.Name, bucketName, *source, v1beta1.AssetDryRun)
+		existingAssets := []assetgroup.CommonAsset{existingAsset}
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{bucketName}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(existingAssets, nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupDryRun))
+		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupAssetsDryRunCompleted))
+	})
+
 	t.Run("AssetError", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
@@ -736,6 +805,267 @@ func TestAssetGroupHandler_Handle_Status(t *testing.T) {
 	})
 }
 
+func TestAssetGroupHandler_Handle_Resync(t *testing.T) {
+	sourceName := v1beta1.AssetGroupSourceName("t1")
+	assetType := v1beta1.AssetGroupSourceType("swag")
+	resyncRequestedAt := "2020-01-01T00:00:00Z"
+
+	t.Run("Requested", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucketName := "test-bucket"
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.Annotations = map[string]string{v1beta1.ResyncRequestedAtAnnotation: resyncRequestedAt}
+		testData.Status.Phase = v1beta1.AssetGroupReady
+		source, ok := getSourceByType(sources, sourceName)
+		g.Expect(ok, true)
+		existingAsset := commonAsset(sourceName, assetType, testData.Name, bucketName, *source, v1beta1.AssetReady)
+		existingAssets := []assetgroup.CommonAsset{existingAsset}
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{bucketName}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(existingAssets, nil).Once()
+		assetSvc.On("Update", ctx, mock.MatchedBy(func(asset assetgroup.CommonAsset) bool {
+			return asset.Name == existingAsset.Name && asset.Annotations[v1beta1.ResyncRequestedAtAnnotation] == resyncRequestedAt
+		})).Return(nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupPending))
+		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupAssetsResyncRequested))
+		g.Expect(status.LastResyncRequestedAt).To(gomega.Equal(resyncRequestedAt))
+	})
+
+	t.Run("AlreadyHandled", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucketName := "test-bucket"
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.Annotations = map[string]string{v1beta1.ResyncRequestedAtAnnotation: resyncRequestedAt}
+		testData.Status.Phase = v1beta1.AssetGroupPending
+		testData.Status.LastResyncRequestedAt = resyncRequestedAt
+		source, ok := getSourceByType(sources, sourceName)
+		g.Expect(ok, true)
+		existingAsset := commonAsset(sourceName, assetType, testData.Name, bucketName, *source, v1beta1.AssetReady)
+		existingAssets := []assetgroup.CommonAsset{existingAsset}
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{bucketName}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(existingAssets, nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupReady))
+		g.Expect(status.LastResyncRequestedAt).To(gomega.Equal(resyncRequestedAt))
+	})
+
+	t.Run("UpdateError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucketName := "test-bucket"
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.Annotations = map[string]string{v1beta1.ResyncRequestedAtAnnotation: resyncRequestedAt}
+		testData.Status.Phase = v1beta1.AssetGroupReady
+		source, ok := getSourceByType(sources, sourceName)
+		g.Expect(ok, true)
+		existingAsset := commonAsset(sourceName, assetType, testData.Name, bucketName, *source, v1beta1.AssetReady)
+		existingAssets := []assetgroup.CommonAsset{existingAsset}
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{bucketName}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(existingAssets, nil).Once()
+		assetSvc.On("Update", ctx, mock.Anything).Return(errors.New("test-err")).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupFailed))
+		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupAssetsResyncFailed))
+		g.Expect(status.LastResyncRequestedAt).To(gomega.BeEmpty())
+	})
+}
+
+func TestAssetGroupHandler_Handle_Suspended(t *testing.T) {
+	sourceName := v1beta1.AssetGroupSourceName("t1")
+	assetType := v1beta1.AssetGroupSourceType("swag")
+
+	t.Run("Suspend", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.Spec.Suspend = true
+		testData.Status.Phase = v1beta1.AssetGroupReady
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupReady))
+		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupSuspended))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		bucketName := "test-bucket"
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.Status.Phase = v1beta1.AssetGroupReady
+		testData.Status.Reason = v1beta1.AssetGroupSuspended
+		source, ok := getSourceByType(sources, sourceName)
+		g.Expect(ok, true)
+		existingAsset := commonAsset(sourceName, assetType, testData.Name, bucketName, *source, v1beta1.AssetReady)
+		existingAssets := []assetgroup.CommonAsset{existingAsset}
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		bucketSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/access": "public"}).Return([]string{bucketName}, nil).Once()
+		assetSvc.On("List", ctx, testData.Namespace, map[string]string{"rafter.kyma-project.io/asset-group": testData.Name}).Return(existingAssets, nil).Once()
+		webhookConfSvc.On("Get", ctx).Return(webhookconfig.AssetWebhookConfigMap{}, nil).Once()
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Phase).To(gomega.Equal(v1beta1.AssetGroupReady))
+		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupAssetsReady))
+	})
+}
+
+func TestAssetGroupHandler_Handle_Expiration(t *testing.T) {
+	sourceName := v1beta1.AssetGroupSourceName("t1")
+	assetType := v1beta1.AssetGroupSourceType("swag")
+
+	t.Run("Scheduled", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		testData.CreationTimestamp = v1.NewTime(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+		testData.Spec.Suspend = true
+		testData.Spec.TTL = &v1.Duration{Duration: time.Hour}
+		testData.Status.Phase = v1beta1.AssetGroupReady
+		testData.Status.Reason = v1beta1.AssetGroupSuspended
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).ToNot(gomega.BeNil())
+		g.Expect(status.Reason).To(gomega.Equal(v1beta1.AssetGroupSuspended))
+		g.Expect(status.ExpirationTime).ToNot(gomega.BeNil())
+		g.Expect(status.ExpirationTime.Time).To(gomega.BeTemporally("==", testData.CreationTimestamp.Add(time.Hour)))
+	})
+
+	t.Run("AlreadyScheduled", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		sources := []v1beta1.Source{testSource(sourceName, assetType, "https://dummy.url", v1beta1.AssetGroupSingle, nil)}
+		testData := testData("halo", "", sources)
+		expiresAt := v1.NewTime(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+		testData.Spec.Suspend = true
+		testData.Spec.ExpiresAt = &expiresAt
+		testData.Status.Phase = v1beta1.AssetGroupReady
+		testData.Status.Reason = v1beta1.AssetGroupSuspended
+		testData.Status.ExpirationTime = expiresAt.DeepCopy()
+
+		assetSvc := new(automock.AssetService)
+		defer assetSvc.AssertExpectations(t)
+		bucketSvc := new(automock.BucketService)
+		defer bucketSvc.AssertExpectations(t)
+		webhookConfSvc := new(amcfg.AssetWebhookConfigService)
+		defer webhookConfSvc.AssertExpectations(t)
+
+		handler := assetgroup.New(log, fakeRecorder(), assetSvc, bucketSvc, webhookConfSvc)
+
+		// When
+		status, err := handler.Handle(ctx, testData, testData.Spec.CommonAssetGroupSpec, testData.Status.CommonAssetGroupStatus)
+
+		// Then
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(status).To(gomega.BeNil())
+	})
+}
+
 func fakeRecorder() record.EventRecorder {
 	return record.NewFakeRecorder(20)
 }
@@ -3,15 +3,18 @@ package bucket
 import (
 	"context"
 	"fmt"
-	"github.com/pkg/errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 )
 
@@ -30,23 +33,32 @@ type MetaAccessor interface {
 	DeepCopyObject() runtime.Object
 }
 
+// FindRemoteNameUsers returns all buckets and cluster buckets that use the given remote bucket name
+type FindRemoteNameUsers func(ctx context.Context, remoteName string) ([]types.NamespacedName, error)
+
 var _ Handler = &bucketHandler{}
 
 type bucketHandler struct {
-	recorder         record.EventRecorder
-	store            store.Store
-	externalEndpoint string
-	log              logr.Logger
-	relistInterval   time.Duration
+	recorder             record.EventRecorder
+	store                store.Store
+	findRemoteNameUsers  FindRemoteNameUsers
+	externalEndpoint     string
+	log                  logr.Logger
+	relistInterval       time.Duration
+	adoptableRemoteNames *regexp.Regexp
 }
 
-func New(log logr.Logger, recorder record.EventRecorder, store store.Store, externalEndpoint string, relistInterval time.Duration) Handler {
+// New creates a bucket handler. Existing remote buckets are only adopted when their names match adoptableRemoteNames,
+// adoption is disabled when it is nil.
+func New(log logr.Logger, recorder record.EventRecorder, store store.Store, findRemoteNameUsersFnc FindRemoteNameUsers, externalEndpoint string, relistInterval time.Duration, adoptableRemoteNames *regexp.Regexp) Handler {
 	return &bucketHandler{
-		recorder:         recorder,
-		store:            store,
-		externalEndpoint: externalEndpoint,
-		log:              log,
-		relistInterval:   relistInterval,
+		recorder:             recorder,
+		store:                store,
+		findRemoteNameUsers:  findRemoteNameUsersFnc,
+		externalEndpoint:     externalEndpoint,
+		log:                  log,
+		relistInterval:       relistInterval,
+		adoptableRemoteNames: adoptableRemoteNames,
 	}
 }
 
@@ -54,15 +66,37 @@ func (h *bucketHandler) Do(ctx context.Context, now time.Time, instance MetaAcce
 	h.logInfof("Start common Bucket handling")
 	defer h.logInfof("Finish common Bucket handling")
 
+	newStatus, err := h.do(ctx, now, instance, spec, status)
+	if newStatus != nil && newStatus.RemoteName != "" {
+		newStatus.StoreClassName = StoreClassName(spec, status)
+		newStatus.Adopted = newStatus.Adopted || newStatus.RemoteName == status.RemoteName && status.Adopted
+		newStatus.DeletionPolicy = h.getDeletionPolicy(spec, newStatus.Adopted)
+	}
+
+	return newStatus, err
+}
+
+// StoreClassName returns the name of the StoreClass the remote bucket belongs to
+func StoreClassName(spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) string {
+	if status.RemoteName != "" {
+		return status.StoreClassName
+	}
+
+	return spec.StoreClassName
+}
+
+func (h *bucketHandler) do(ctx context.Context, now time.Time, instance MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
 	switch {
 	case h.isOnDelete(instance):
-		return h.onDelete(ctx, instance, status)
+		return h.onDelete(ctx, instance, spec, status)
+	case h.isOnSuspended(spec):
+		return h.onSuspended(instance, status), nil
 	case h.isOnAddOrUpdate(instance, status):
-		return h.onAddOrUpdate(instance, spec, status)
+		return h.onAddOrUpdate(ctx, instance, spec, status)
 	case h.isOnReady(status, now):
 		return h.onReady(instance, spec, status)
 	case h.isOnFailed(status):
-		return h.onFailed(instance, spec, status)
+		return h.onFailed(ctx, instance, spec, status)
 	default:
 		h.logInfof("Action not taken")
 		return nil, nil
@@ -81,16 +115,37 @@ func (*bucketHandler) isOnFailed(status v1beta1.CommonBucketStatus) bool {
 	return status.Phase == v1beta1.BucketFailed
 }
 
+func (*bucketHandler) isOnSuspended(spec v1beta1.CommonBucketSpec) bool {
+	return spec.Suspend
+}
+
 func (*bucketHandler) isOnDelete(object MetaAccessor) bool {
 	return !object.GetDeletionTimestamp().IsZero()
 }
 
-func (h *bucketHandler) onFailed(object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+func (h *bucketHandler) onSuspended(object MetaAccessor, status v1beta1.CommonBucketStatus) *v1beta1.CommonBucketStatus {
+	if status.Reason == v1beta1.BucketSuspended {
+		return nil
+	}
+
+	h.logInfof("Bucket reconciliation is suspended")
+	h.recordNormalEventf(object, v1beta1.BucketSuspended)
+	suspended := status.DeepCopy()
+	suspended.Reason = v1beta1.BucketSuspended
+	suspended.Message = v1beta1.BucketSuspended.Message()
+	suspended.LastHeartbeatTime = v1.Now()
+
+	return suspended
+}
+
+func (h *bucketHandler) onFailed(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
 	switch status.Reason {
 	case v1beta1.BucketNotFound:
-		return h.onAddOrUpdate(object, spec, status)
+		return h.onAddOrUpdate(ctx, object, spec, status)
 	case v1beta1.BucketCreationFailure:
-		return h.onAddOrUpdate(object, spec, status)
+		return h.onAddOrUpdate(ctx, object, spec, status)
+	case v1beta1.BucketRemoteNameConflict:
+		return h.onAddOrUpdate(ctx, object, spec, status)
 	case v1beta1.BucketVerificationFailure:
 		return h.onReady(object, spec, status)
 	case v1beta1.BucketPolicyUpdateFailed:
@@ -121,7 +176,7 @@ func (h *bucketHandler) onReady(object MetaAccessor, spec v1beta1.CommonBucketSp
 	}
 	if equal {
 		h.logInfof("Bucket is up-to-date")
-		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketReady, v1beta1.BucketPolicyUpdated), nil
+		return h.getReadyStatus(object, spec, status.RemoteName, status.URL, status.Adopted, v1beta1.BucketPolicyUpdated), nil
 	}
 
 	h.logInfof("Updating bucket policy")
@@ -133,24 +188,74 @@ func (h *bucketHandler) onReady(object MetaAccessor, spec v1beta1.CommonBucketSp
 	h.recordNormalEventf(object, v1beta1.BucketPolicyUpdated)
 	h.logInfof("Bucket policy updated")
 
-	return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketReady, v1beta1.BucketPolicyUpdated), nil
+	return h.getReadyStatus(object, spec, status.RemoteName, status.URL, status.Adopted, v1beta1.BucketPolicyUpdated), nil
 }
 
-func (h *bucketHandler) onAddOrUpdate(object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+func (h *bucketHandler) onAddOrUpdate(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+	if err := h.validateEncryption(object, spec); err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketEncryptionInvalid, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketEncryptionInvalid, err.Error()), nil
+	}
+
+	if err := h.validateRemoteName(spec.RemoteName, status.RemoteName); err != nil {
+		h.recordWarningEventf(object, v1beta1.BucketRemoteNameInvalid, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketRemoteNameInvalid, err.Error()), nil
+	}
+
+	if status.RemoteName != "" && status.StoreClassName != spec.StoreClassName {
+		err := errors.Errorf("storeClassName cannot be changed from %q to %q", status.StoreClassName, spec.StoreClassName)
+		h.recordWarningEventf(object, v1beta1.BucketStoreClassInvalid, err.Error())
+		return h.getStatus(object, status.RemoteName, status.URL, v1beta1.BucketFailed, v1beta1.BucketStoreClassInvalid, err.Error()), nil
+	}
+
 	h.logInfof("Checking if bucket was previously created")
 	if status.RemoteName != "" {
 		h.logInfof("Bucket was created")
 		return h.onReady(object, spec, status)
 	}
 
-	h.logInfof("Creating bucket")
-	remoteName, err := h.store.CreateBucket(object.GetNamespace(), object.GetName(), string(spec.Region))
-	if err != nil {
-		h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
-		return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+	remoteName := spec.RemoteName
+	adopted := false
+	if remoteName != "" {
+		h.logInfof("Checking if remote bucket %s is used by other buckets", remoteName)
+		users, err := h.findOtherRemoteNameUsers(ctx, object, remoteName)
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+		}
+		if len(users) > 0 {
+			h.recordWarningEventf(object, v1beta1.BucketRemoteNameConflict, remoteName, strings.Join(users, ", "))
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketRemoteNameConflict, remoteName, strings.Join(users, ", ")), nil
+		}
+
+		h.logInfof("Ensuring bucket %s", remoteName)
+		created, err := h.store.EnsureBucket(remoteName, string(spec.Region))
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+		}
+		if created {
+			h.recordNormalEventf(object, v1beta1.BucketCreated)
+			h.logInfof("Bucket created")
+		} else if !h.isAdoptable(remoteName) {
+			h.recordWarningEventf(object, v1beta1.BucketAdoptionNotAllowed, remoteName)
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketAdoptionNotAllowed, remoteName), nil
+		} else {
+			h.recordNormalEventf(object, v1beta1.BucketAdopted, remoteName)
+			h.logInfof("Bucket adopted")
+			adopted = true
+		}
+	} else {
+		h.logInfof("Creating bucket")
+		name, err := h.store.CreateBucket(object.GetNamespace(), object.GetName(), string(spec.Region))
+		if err != nil {
+			h.recordWarningEventf(object, v1beta1.BucketCreationFailure, err.Error())
+			return h.getStatus(object, "", "", v1beta1.BucketFailed, v1beta1.BucketCreationFailure, err.Error()), err
+		}
+		h.recordNormalEventf(object, v1beta1.BucketCreated)
+		h.logInfof("Bucket created")
+		remoteName = name
 	}
-	h.recordNormalEventf(object, v1beta1.BucketCreated)
-	h.logInfof("Bucket created")
 
 	externalUrl := h.getBucketUrl(remoteName)
 
@@ -162,16 +267,37 @@ func (h *bucketHandler) onAddOrUpdate(object MetaAccessor, spec v1beta1.CommonBu
 	h.recordNormalEventf(object, v1beta1.BucketPolicyUpdated)
 	h.logInfof("Bucket policy updated")
 
-	return h.getStatus(object, remoteName, externalUrl, v1beta1.BucketReady, v1beta1.BucketPolicyUpdated), nil
+	return h.getReadyStatus(object, spec, remoteName, externalUrl, adopted, v1beta1.BucketPolicyUpdated), nil
 }
 
-func (h *bucketHandler) onDelete(ctx context.Context, object MetaAccessor, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
+func (h *bucketHandler) onDelete(ctx context.Context, object MetaAccessor, spec v1beta1.CommonBucketSpec, status v1beta1.CommonBucketStatus) (*v1beta1.CommonBucketStatus, error) {
 	h.logInfof("Deleting Bucket")
 	if status.RemoteName == "" || status.Reason == v1beta1.BucketNotFound {
 		h.logInfof("Nothing to delete, there is no remote bucket")
 		return nil, nil
 	}
 
+	deletionPolicy := h.getDeletionPolicy(spec, status.Adopted)
+	switch deletionPolicy {
+	case v1beta1.BucketDeletionPolicyRetain:
+		if err := h.store.MarkRetained(ctx, status.RemoteName); err != nil {
+			return nil, errors.Wrap(err, "while marking remote bucket as retained")
+		}
+		h.recordNormalEventf(object, v1beta1.BucketRetained, status.RemoteName, deletionPolicy)
+		h.logInfof("Remote bucket %s retained due to deletion policy %s", status.RemoteName, deletionPolicy)
+		return nil, nil
+	case v1beta1.BucketDeletionPolicyOrphan:
+		if err := h.store.DeleteObjects(ctx, status.RemoteName, ""); err != nil {
+			return nil, errors.Wrap(err, "while deleting content of remote bucket")
+		}
+		if err := h.store.MarkRetained(ctx, status.RemoteName); err != nil {
+			return nil, errors.Wrap(err, "while marking remote bucket as retained")
+		}
+		h.recordNormalEventf(object, v1beta1.BucketRetained, status.RemoteName, deletionPolicy)
+		h.logInfof("Content of remote bucket %s deleted, bucket retained due to deletion policy %s", status.RemoteName, deletionPolicy)
+		return nil, nil
+	}
+
 	if err := h.store.DeleteBucket(ctx, status.RemoteName); err != nil {
 		return nil, errors.Wrap(err, "while deleting remote bucket")
 	}
@@ -180,6 +306,92 @@ func (h *bucketHandler) onDelete(ctx context.Context, object MetaAccessor, statu
 	return nil, nil
 }
 
+// getDeletionPolicy defaults to Retain for adopted buckets, as their content was not created by the resource
+func (*bucketHandler) getDeletionPolicy(spec v1beta1.CommonBucketSpec, adopted bool) v1beta1.BucketDeletionPolicy {
+	switch {
+	case spec.DeletionPolicy != "":
+		return spec.DeletionPolicy
+	case adopted:
+		return v1beta1.BucketDeletionPolicyRetain
+	default:
+		return v1beta1.BucketDeletionPolicyDelete
+	}
+}
+
+func (h *bucketHandler) isAdoptable(remoteName string) bool {
+	return h.adoptableRemoteNames != nil && h.adoptableRemoteNames.MatchString(remoteName)
+}
+
+func (*bucketHandler) validateRemoteName(remoteName, currentRemoteName string) error {
+	if remoteName == "" {
+		return nil
+	}
+
+	if err := store.ValidateBucketName(remoteName); err != nil {
+		return err
+	}
+
+	if currentRemoteName != "" && currentRemoteName != remoteName {
+		return errors.Errorf("remoteName cannot be changed from %s to %s", currentRemoteName, remoteName)
+	}
+
+	return nil
+}
+
+func (h *bucketHandler) findOtherRemoteNameUsers(ctx context.Context, object MetaAccessor, remoteName string) ([]string, error) {
+	users, err := h.findRemoteNameUsers(ctx, remoteName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while finding buckets using remote bucket %s", remoteName)
+	}
+
+	self := types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}
+	var others []string
+	for _, user := range users {
+		if user == self {
+			continue
+		}
+		others = append(others, user.String())
+	}
+
+	return others, nil
+}
+
+// validateEncryption rejects SSE-C on buckets with a public policy, as objects encrypted with a customer key
+// cannot be read without the key, and keys from other namespaces for namespaced buckets
+func (*bucketHandler) validateEncryption(object MetaAccessor, spec v1beta1.CommonBucketSpec) error {
+	encryption := spec.Encryption
+	if encryption == nil || encryption.Mode != v1beta1.BucketEncryptionSSEC {
+		return nil
+	}
+
+	ref := encryption.KeySecretRef
+	switch {
+	case ref == nil || ref.Name == "" || ref.Key == "":
+		return errors.New("SSE-C mode requires keySecretRef with name and key")
+	case ref.Namespace == "" && object.GetNamespace() == "":
+		return errors.New("keySecretRef of a cluster-wide bucket requires namespace")
+	case ref.Namespace != "" && object.GetNamespace() != "" && ref.Namespace != object.GetNamespace():
+		return errors.Errorf("keySecretRef of a bucket in namespace %s cannot point to namespace %s", object.GetNamespace(), ref.Namespace)
+	case spec.Policy != "" && spec.Policy != v1beta1.BucketPolicyNone:
+		return errors.Errorf("SSE-C mode cannot be used with bucket policy %s", spec.Policy)
+	}
+
+	return nil
+}
+
+func (*bucketHandler) resolveEncryption(object MetaAccessor, encryption *v1beta1.BucketEncryption) *v1beta1.BucketEncryption {
+	if encryption == nil {
+		return nil
+	}
+
+	resolved := encryption.DeepCopy()
+	if resolved.KeySecretRef != nil && resolved.KeySecretRef.Namespace == "" {
+		resolved.KeySecretRef.Namespace = object.GetNamespace()
+	}
+
+	return resolved
+}
+
 func (h *bucketHandler) getBucketUrl(name string) string {
 	return fmt.Sprintf("%s/%s", h.externalEndpoint, name)
 }
@@ -200,6 +412,13 @@ func (h *bucketHandler) recordEventf(object MetaAccessor, eventType string, reas
 	h.recorder.Eventf(object, eventType, reason.String(), reason.Message(), args...)
 }
 
+func (h *bucketHandler) getReadyStatus(object MetaAccessor, spec v1beta1.CommonBucketSpec, remoteName, url string, adopted bool, reason v1beta1.BucketReason, args ...interface{}) *v1beta1.CommonBucketStatus {
+	status := h.getStatus(object, remoteName, url, v1beta1.BucketReady, reason, args...)
+	status.Encryption = h.resolveEncryption(object, spec.Encryption)
+	status.Adopted = adopted
+	return status
+}
+
 func (*bucketHandler) getStatus(object MetaAccessor, remoteName, url string, phase v1beta1.BucketPhase, reason v1beta1.BucketReason, args ...interface{}) *v1beta1.CommonBucketStatus {
 	return &v1beta1.CommonBucketStatus{
 		LastHeartbeatTime:  v1.Now(),
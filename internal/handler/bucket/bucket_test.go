@@ -3,6 +3,7 @@ package bucket_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
@@ -31,7 +33,7 @@ func TestBucketHandler_Handle_Default(t *testing.T) {
 	store := new(automock.Store)
 	defer store.AssertExpectations(t)
 
-	handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+	handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 	// When
 	status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -61,7 +63,7 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, nil).Once()
 		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -91,7 +93,7 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
 		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, url, relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -121,7 +123,7 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return("", errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, url, relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -151,7 +153,7 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
 		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, url, relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -166,6 +168,452 @@ func TestBucketHandler_Handle_OnAddOrUpdate(t *testing.T) {
 	})
 }
 
+func TestBucketHandler_Handle_Encryption(t *testing.T) {
+	t.Run("SSE-C", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyNone)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{
+			Mode:         v1beta1.BucketEncryptionSSEC,
+			KeySecretRef: &v1beta1.SecretKeyRef{Name: "test-secret", Key: "key"},
+		}
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+		url := "http://localhost"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, url, relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Encryption).ToNot(BeNil())
+		g.Expect(status.Encryption.Mode).To(Equal(v1beta1.BucketEncryptionSSEC))
+		g.Expect(status.Encryption.KeySecretRef.Namespace).To(Equal(data.Namespace))
+		g.Expect(data.Spec.Encryption.KeySecretRef.Namespace).To(BeEmpty())
+	})
+
+	t.Run("MissingKeySecretRef", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyNone)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{Mode: v1beta1.BucketEncryptionSSEC}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionInvalid))
+	})
+
+	t.Run("ClusterWideWithoutNamespace", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyNone)
+		data.Namespace = ""
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{
+			Mode:         v1beta1.BucketEncryptionSSEC,
+			KeySecretRef: &v1beta1.SecretKeyRef{Name: "test-secret", Key: "key"},
+		}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionInvalid))
+	})
+	t.Run("KeySecretRefInOtherNamespace", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyNone)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{
+			Mode:         v1beta1.BucketEncryptionSSEC,
+			KeySecretRef: &v1beta1.SecretKeyRef{Name: "test-secret", Key: "key", Namespace: "other"},
+		}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionInvalid))
+	})
+
+	t.Run("PublicPolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.Encryption = &v1beta1.BucketEncryption{
+			Mode:         v1beta1.BucketEncryptionSSEC,
+			KeySecretRef: &v1beta1.SecretKeyRef{Name: "test-secret", Key: "key"},
+		}
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketEncryptionInvalid))
+	})
+}
+
+func TestBucketHandler_Handle_RemoteName(t *testing.T) {
+	t.Run("Adopted", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "existing-bucket"
+		url := "http://localhost"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("EnsureBucket", data.Spec.RemoteName, string(data.Spec.Region)).Return(false, nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, remoteNameUsers(data.Namespace, data.Name), url, relistInterval, regexp.MustCompile("^existing-"))
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.RemoteName).To(Equal(data.Spec.RemoteName))
+		g.Expect(status.URL).To(Equal(fmt.Sprintf("%s/%s", url, data.Spec.RemoteName)))
+		g.Expect(status.Adopted).To(BeTrue())
+		g.Expect(status.DeletionPolicy).To(Equal(v1beta1.BucketDeletionPolicyRetain))
+	})
+
+	t.Run("AdoptionNotAllowed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "system-private-123"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("EnsureBucket", data.Spec.RemoteName, string(data.Spec.Region)).Return(false, nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, regexp.MustCompile("^existing-"))
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketAdoptionNotAllowed))
+		g.Expect(status.RemoteName).To(BeEmpty())
+	})
+
+	t.Run("AdoptedRetainedOnDelete", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.DeletionTimestamp = &v1.Time{Time: now}
+		data.Status.Phase = v1beta1.BucketFailed
+		data.Status.Reason = v1beta1.BucketPolicyUpdateFailed
+		data.Status.RemoteName = "existing-bucket"
+		data.Status.Adopted = true
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("MarkRetained", ctx, "existing-bucket").Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeNil())
+	})
+
+	t.Run("Created", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "new-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("EnsureBucket", data.Spec.RemoteName, string(data.Spec.Region)).Return(true, nil).Once()
+		store.On("SetBucketPolicy", data.Spec.RemoteName, data.Spec.Policy).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.RemoteName).To(Equal(data.Spec.RemoteName))
+		g.Expect(status.DeletionPolicy).To(Equal(v1beta1.BucketDeletionPolicyDelete))
+	})
+
+	t.Run("EnsureBucketError", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "new-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("EnsureBucket", data.Spec.RemoteName, string(data.Spec.Region)).Return(false, errors.New("test-err")).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketCreationFailure))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "Invalid_Bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketRemoteNameInvalid))
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(2)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.RemoteName = "old-bucket"
+		data.Spec.RemoteName = "new-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketRemoteNameInvalid))
+		g.Expect(status.RemoteName).To(Equal(data.Status.RemoteName))
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.RemoteName = "existing-bucket"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, remoteNameUsers("other-ns", "other-bucket"), "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketRemoteNameConflict))
+		g.Expect(status.Message).To(ContainSubstring("other-ns/other-bucket"))
+	})
+}
+
+func TestBucketHandler_Handle_StoreClass(t *testing.T) {
+	t.Run("Created", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(1)
+		data.Status.ObservedGeneration = int64(2)
+		data.Spec.StoreClassName = "test-class"
+		remoteName := fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
+		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.StoreClassName).To(Equal(data.Spec.StoreClassName))
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.ObjectMeta.Generation = int64(2)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+		data.Spec.StoreClassName = "test-class"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "http://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketFailed))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketStoreClassInvalid))
+		g.Expect(status.RemoteName).To(Equal(data.Status.RemoteName))
+		g.Expect(status.StoreClassName).To(BeEmpty())
+	})
+}
+
 func TestBucketHandler_Handle_OnReady(t *testing.T) {
 	t.Run("NotTaken", func(t *testing.T) {
 		// Given
@@ -182,7 +630,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -211,7 +659,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(true, nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -241,7 +689,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 
 		store.On("BucketExists", data.Status.RemoteName).Return(false, nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -271,7 +719,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 
 		store.On("BucketExists", data.Status.RemoteName).Return(false, errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -303,7 +751,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, nil).Once()
 		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -335,7 +783,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, nil).Once()
 		store.On("SetBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -366,7 +814,7 @@ func TestBucketHandler_Handle_OnReady(t *testing.T) {
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(false, errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -400,7 +848,7 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
 		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, url, relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -433,7 +881,7 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(true, nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -464,7 +912,7 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		store.On("BucketExists", data.Status.RemoteName).Return(true, nil).Once()
 		store.On("CompareBucketPolicy", data.Status.RemoteName, data.Spec.Policy).Return(true, nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -496,7 +944,7 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 		store.On("CreateBucket", data.Namespace, data.Name, string(data.Spec.Region)).Return(remoteName, nil).Once()
 		store.On("SetBucketPolicy", remoteName, data.Spec.Policy).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, url, relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, url, relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -511,6 +959,66 @@ func TestBucketHandler_Handle_OnFailed(t *testing.T) {
 	})
 }
 
+func TestBucketHandler_Handle_Suspended(t *testing.T) {
+	t.Run("Suspend", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.Spec.Suspend = true
+		data.ObjectMeta.Generation = int64(2)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.Reason = v1beta1.BucketPolicyUpdated
+		data.Status.RemoteName = "test-remote-name"
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).ToNot(BeZero())
+		g.Expect(status.Phase).To(Equal(v1beta1.BucketReady))
+		g.Expect(status.Reason).To(Equal(v1beta1.BucketSuspended))
+		g.Expect(status.RemoteName).To(Equal("test-remote-name"))
+		g.Expect(status.ObservedGeneration).To(Equal(int64(1)))
+	})
+
+	t.Run("AlreadySuspended", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		data.Spec.Suspend = true
+		data.ObjectMeta.Generation = int64(2)
+		data.Status.ObservedGeneration = int64(1)
+		data.Status.Phase = v1beta1.BucketReady
+		data.Status.Reason = v1beta1.BucketSuspended
+		data.Status.LastHeartbeatTime = v1.NewTime(now.Add(-time.Hour))
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+}
+
 func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 	t.Run("WithRemoteName", func(t *testing.T) {
 		// Given
@@ -528,7 +1036,62 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 
 		store.On("DeleteBucket", ctx, data.Status.RemoteName).Return(nil).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("RetainPolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Spec.DeletionPolicy = v1beta1.BucketDeletionPolicyRetain
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("MarkRetained", ctx, data.Status.RemoteName).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
+
+		// When
+		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
+
+		// Then
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(BeZero())
+	})
+
+	t.Run("OrphanPolicy", func(t *testing.T) {
+		// Given
+		g := NewGomegaWithT(t)
+		ctx := context.TODO()
+		relistInterval := time.Minute
+		now := time.Now()
+		data := testData("test-bucket", v1beta1.BucketPolicyReadOnly)
+		deletionTimestamp := v1.Now()
+		data.ObjectMeta.DeletionTimestamp = &deletionTimestamp
+		data.Spec.DeletionPolicy = v1beta1.BucketDeletionPolicyOrphan
+		data.Status.RemoteName = fmt.Sprintf("%s-123", data.Name)
+
+		store := new(automock.Store)
+		defer store.AssertExpectations(t)
+
+		store.On("DeleteObjects", ctx, data.Status.RemoteName, "").Return(nil).Once()
+		store.On("MarkRetained", ctx, data.Status.RemoteName).Return(nil).Once()
+
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -551,7 +1114,7 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -576,7 +1139,7 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 		store := new(automock.Store)
 		defer store.AssertExpectations(t)
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -602,7 +1165,7 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 
 		store.On("DeleteBucket", ctx, data.Status.RemoteName).Return(errors.New("nope")).Once()
 
-		handler := bucket.New(log, fakeRecorder(), store, "https://localhost", relistInterval)
+		handler := bucket.New(log, fakeRecorder(), store, noRemoteNameUsers, "https://localhost", relistInterval, nil)
 
 		// When
 		status, err := handler.Do(ctx, now, data, data.Spec.CommonBucketSpec, data.Status.CommonBucketStatus)
@@ -613,6 +1176,16 @@ func TestBucketHandler_Handle_OnDelete(t *testing.T) {
 	})
 }
 
+func noRemoteNameUsers(ctx context.Context, remoteName string) ([]types.NamespacedName, error) {
+	return nil, nil
+}
+
+func remoteNameUsers(namespace, name string) bucket.FindRemoteNameUsers {
+	return func(ctx context.Context, remoteName string) ([]types.NamespacedName, error) {
+		return []types.NamespacedName{{Namespace: namespace, Name: name}}, nil
+	}
+}
+
 func fakeRecorder() record.EventRecorder {
 	return record.NewFakeRecorder(20)
 }
@@ -4,6 +4,7 @@ package automock
 
 import (
 	context "context"
+	io "io"
 
 	minio "github.com/minio/minio-go"
 	mock "github.com/stretchr/testify/mock"
@@ -77,6 +78,29 @@ func (_m *MinioClient) GetBucketPolicy(bucketName string) (string, error) {
 	return r0, r1
 }
 
+// ListBuckets provides a mock function with given fields:
+func (_m *MinioClient) ListBuckets() ([]minio.BucketInfo, error) {
+	ret := _m.Called()
+
+	var r0 []minio.BucketInfo
+	if rf, ok := ret.Get(0).(func() []minio.BucketInfo); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]minio.BucketInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListObjects provides a mock function with given fields: bucketName, objectPrefix, recursive, doneCh
 func (_m *MinioClient) ListObjects(bucketName string, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo {
 	ret := _m.Called(bucketName, objectPrefix, recursive, doneCh)
@@ -107,6 +131,27 @@ func (_m *MinioClient) MakeBucket(bucketName string, location string) error {
 	return r0
 }
 
+// PutObjectWithContext provides a mock function with given fields: ctx, bucketName, objectName, reader, objectSize, opts
+func (_m *MinioClient) PutObjectWithContext(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+	ret := _m.Called(ctx, bucketName, objectName, reader, objectSize, opts)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, int64, minio.PutObjectOptions) int64); ok {
+		r0 = rf(ctx, bucketName, objectName, reader, objectSize, opts)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, io.Reader, int64, minio.PutObjectOptions) error); ok {
+		r1 = rf(ctx, bucketName, objectName, reader, objectSize, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveBucket provides a mock function with given fields: bucketName
 func (_m *MinioClient) RemoveBucket(bucketName string) error {
 	ret := _m.Called(bucketName)
@@ -150,3 +195,24 @@ func (_m *MinioClient) SetBucketPolicy(bucketName string, policy string) error {
 
 	return r0
 }
+
+// StatObject provides a mock function with given fields: bucketName, objectName, opts
+func (_m *MinioClient) StatObject(bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	ret := _m.Called(bucketName, objectName, opts)
+
+	var r0 minio.ObjectInfo
+	if rf, ok := ret.Get(0).(func(string, string, minio.StatObjectOptions) minio.ObjectInfo); ok {
+		r0 = rf(bucketName, objectName, opts)
+	} else {
+		r0 = ret.Get(0).(minio.ObjectInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, minio.StatObjectOptions) error); ok {
+		r1 = rf(bucketName, objectName, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
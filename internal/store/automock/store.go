@@ -5,8 +5,12 @@ package automock
 import (
 	context "context"
 
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 
+	store "github.com/kyma-project/rafter/internal/store"
+
 	v1beta1 "github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 )
 
@@ -57,27 +61,6 @@ func (_m *Store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy)
 	return r0, r1
 }
 
-// ContainsAllObjects provides a mock function with given fields: ctx, bucketName, assetName, files
-func (_m *Store) ContainsAllObjects(ctx context.Context, bucketName string, assetName string, files []string) (bool, error) {
-	ret := _m.Called(ctx, bucketName, assetName, files)
-
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) bool); ok {
-		r0 = rf(ctx, bucketName, assetName, files)
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
-		r1 = rf(ctx, bucketName, assetName, files)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // CreateBucket provides a mock function with given fields: namespace, crName, region
 func (_m *Store) CreateBucket(namespace string, crName string, region string) (string, error) {
 	ret := _m.Called(namespace, crName, region)
@@ -127,6 +110,71 @@ func (_m *Store) DeleteObjects(ctx context.Context, bucketName string, prefix st
 	return r0
 }
 
+// EnsureBucket provides a mock function with given fields: name, region
+func (_m *Store) EnsureBucket(name string, region string) (bool, error) {
+	ret := _m.Called(name, region)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(name, region)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(name, region)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsRetained provides a mock function with given fields: name
+func (_m *Store) IsRetained(name string) (bool, error) {
+	ret := _m.Called(name)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBuckets provides a mock function with given fields:
+func (_m *Store) ListBuckets() (map[string]time.Time, error) {
+	ret := _m.Called()
+
+	var r0 map[string]time.Time
+	if rf, ok := ret.Get(0).(func() map[string]time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListObjects provides a mock function with given fields: ctx, bucketName, prefix
 func (_m *Store) ListObjects(ctx context.Context, bucketName string, prefix string) ([]string, error) {
 	ret := _m.Called(ctx, bucketName, prefix)
@@ -150,13 +198,36 @@ func (_m *Store) ListObjects(ctx context.Context, bucketName string, prefix stri
 	return r0, r1
 }
 
-// PutObjects provides a mock function with given fields: ctx, bucketName, assetName, sourceBasePath, files
-func (_m *Store) PutObjects(ctx context.Context, bucketName string, assetName string, sourceBasePath string, files []string) error {
-	ret := _m.Called(ctx, bucketName, assetName, sourceBasePath, files)
+// ListPrefixes provides a mock function with given fields: ctx, bucketName
+func (_m *Store) ListPrefixes(ctx context.Context, bucketName string) (map[string]time.Time, error) {
+	ret := _m.Called(ctx, bucketName)
+
+	var r0 map[string]time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]time.Time); ok {
+		r0 = rf(ctx, bucketName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, bucketName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkRetained provides a mock function with given fields: ctx, name
+func (_m *Store) MarkRetained(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) error); ok {
-		r0 = rf(ctx, bucketName, assetName, sourceBasePath, files)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -164,6 +235,29 @@ func (_m *Store) PutObjects(ctx context.Context, bucketName string, assetName st
 	return r0
 }
 
+// PutObjects provides a mock function with given fields: ctx, bucketName, assetName, sourceBasePath, files, encryption
+func (_m *Store) PutObjects(ctx context.Context, bucketName string, assetName string, sourceBasePath string, files []string, encryption *store.Encryption) (map[string]store.FileInfo, error) {
+	ret := _m.Called(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+
+	var r0 map[string]store.FileInfo
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, *store.Encryption) map[string]store.FileInfo); ok {
+		r0 = rf(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]store.FileInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string, *store.Encryption) error); ok {
+		r1 = rf(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetBucketPolicy provides a mock function with given fields: name, policy
 func (_m *Store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error {
 	ret := _m.Called(name, policy)
@@ -177,3 +271,26 @@ func (_m *Store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error
 
 	return r0
 }
+
+// VerifyObjects provides a mock function with given fields: ctx, bucketName, assetName, digests, encryption
+func (_m *Store) VerifyObjects(ctx context.Context, bucketName string, assetName string, digests map[string]store.ObjectDigest, encryption *store.Encryption) (*store.Verification, error) {
+	ret := _m.Called(ctx, bucketName, assetName, digests, encryption)
+
+	var r0 *store.Verification
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, map[string]store.ObjectDigest, *store.Encryption) *store.Verification); ok {
+		r0 = rf(ctx, bucketName, assetName, digests, encryption)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.Verification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, map[string]store.ObjectDigest, *store.Encryption) error); ok {
+		r1 = rf(ctx, bucketName, assetName, digests, encryption)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
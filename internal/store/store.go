@@ -2,33 +2,58 @@ package store
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	"github.com/kyma-project/rafter/internal/storeclient"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/minio/minio-go/pkg/policy"
+	"github.com/minio/minio-go/pkg/s3utils"
 	"github.com/pkg/errors"
 )
 
 type Config struct {
 	Endpoint           string `envconfig:"default=minio.kyma.local"`
 	ExternalEndpoint   string `envconfig:"default=https://minio.kyma.local"`
-	AccessKey          string `envconfig:""`
-	SecretKey          string `envconfig:""`
+	AccessKey          string `envconfig:"optional"`
+	SecretKey          string `envconfig:"optional"`
 	UseSSL             bool   `envconfig:"default=true"`
 	UploadWorkersCount int    `envconfig:"default=10"`
+	BucketNameTemplate string `envconfig:"optional"`
+	Credentials        storeclient.CredentialsConfig
 }
 
+// BucketNameData is the data passed to the template used for generated bucket names
+type BucketNameData struct {
+	Namespace string
+	Name      string
+	Suffix    string
+}
+
+const defaultBucketNameTemplate = "{{ .Name }}-{{ .Suffix }}"
+
 //go:generate mockery -name=MinioClient -output=automock -outpkg=automock -case=underscore
 type MinioClient interface {
 	FPutObjectWithContext(ctx context.Context, bucketName, objectName, filePath string, opts minio.PutObjectOptions) (n int64, err error)
+	PutObjectWithContext(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error)
 	ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo
 	MakeBucket(bucketName string, location string) error
 	BucketExists(bucketName string) (bool, error)
@@ -36,37 +61,115 @@ type MinioClient interface {
 	SetBucketPolicy(bucketName, policy string) error
 	GetBucketPolicy(bucketName string) (string, error)
 	RemoveObjectsWithContext(ctx context.Context, bucketName string, objectsCh <-chan string) <-chan minio.RemoveObjectError
+	StatObject(bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
+	ListBuckets() ([]minio.BucketInfo, error)
 }
 
 //go:generate mockery -name=Store -output=automock -outpkg=automock -case=underscore
 type Store interface {
 	CreateBucket(namespace, crName, region string) (string, error)
+	EnsureBucket(name, region string) (bool, error)
 	BucketExists(name string) (bool, error)
+	ListBuckets() (map[string]time.Time, error)
 	DeleteBucket(ctx context.Context, name string) error
+	MarkRetained(ctx context.Context, name string) error
+	IsRetained(name string) (bool, error)
 	SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error
 	CompareBucketPolicy(name string, expected v1beta1.BucketPolicy) (bool, error)
-	ContainsAllObjects(ctx context.Context, bucketName, assetName string, files []string) (bool, error)
-	PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string) error
+	VerifyObjects(ctx context.Context, bucketName, assetName string, digests map[string]ObjectDigest, encryption *Encryption) (*Verification, error)
+	PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, encryption *Encryption) (map[string]FileInfo, error)
 	DeleteObjects(ctx context.Context, bucketName, prefix string) error
 	ListObjects(ctx context.Context, bucketName, prefix string) ([]string, error)
+	ListPrefixes(ctx context.Context, bucketName string) (map[string]time.Time, error)
+}
+
+// Encryption describes server-side encryption applied to uploaded objects
+type Encryption struct {
+	Mode v1beta1.BucketEncryptionMode
+	Key  []byte
 }
 
+// Verification describes differences between the expected and the stored asset content. Unexpected objects
+// don't make the content outdated and are never removed, as they are only reported.
+type Verification struct {
+	Missing    []string
+	Modified   []string
+	Unexpected []string
+}
+
+// IsUpToDate returns true if all expected objects are stored with the expected content
+func (v *Verification) IsUpToDate() bool {
+	return len(v.Missing) == 0 && len(v.Modified) == 0
+}
+
+// FileInfo describes an uploaded file
+type FileInfo struct {
+	Size        int64
+	Md5         string
+	Sha256      string
+	ContentType string
+}
+
+// ObjectDigest describes the expected content of a stored object. Empty fields are not verified.
+type ObjectDigest struct {
+	Size   int64
+	Md5    string
+	Sha256 string
+}
+
+// DigestMetadataKey is the user metadata key holding the SHA-256 digest of the uploaded object
+const DigestMetadataKey = "Sha256"
+
+// RetainedMarkerKey is the object marking a remote bucket that was kept on purpose after its resource had been
+// deleted. Keys without a slash are never taken for asset content.
+const RetainedMarkerKey = ".rafter-retained"
+
+// NamespacedObjectsPrefix is the top-level prefix of objects that Assets store in shared ClusterBuckets.
+// Resource names can't contain an underscore, so it never collides with objects of ClusterAssets.
+const NamespacedObjectsPrefix = "_namespaces"
+
 type store struct {
-	client            MinioClient
-	uploadWorkerCount int
+	client             MinioClient
+	uploadWorkerCount  int
+	bucketNameTemplate *template.Template
 }
 
-func New(client MinioClient, uploadWorkerCount int) Store {
+// New returns a Store that generates bucket names from the given template, or from the default one when it is nil
+func New(client MinioClient, uploadWorkerCount int, bucketNameTemplate *template.Template) Store {
+	if bucketNameTemplate == nil {
+		bucketNameTemplate = template.Must(ParseBucketNameTemplate(""))
+	}
+
 	return &store{
-		client:            client,
-		uploadWorkerCount: uploadWorkerCount,
+		client:             client,
+		uploadWorkerCount:  uploadWorkerCount,
+		bucketNameTemplate: bucketNameTemplate,
+	}
+}
+
+// ParseBucketNameTemplate parses the template used for generated bucket names, falling back to the default one
+func ParseBucketNameTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = defaultBucketNameTemplate
+	}
+
+	tmpl, err := template.New("bucketName").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while parsing bucket name template %s", text)
 	}
+
+	return tmpl, nil
+}
+
+// ValidateBucketName checks if the name meets the S3 bucket naming rules
+func ValidateBucketName(name string) error {
+	return s3utils.CheckValidBucketNameStrict(name)
 }
 
 // Bucket
 
 func (s *store) CreateBucket(namespace, crName, region string) (string, error) {
-	bucketName, err := s.findBucketName(crName)
+	bucketName, err := s.findBucketName(namespace, crName)
 	if err != nil {
 		return "", err
 	}
@@ -79,6 +182,27 @@ func (s *store) CreateBucket(namespace, crName, region string) (string, error) {
 	return bucketName, nil
 }
 
+func (s *store) EnsureBucket(name, region string) (bool, error) {
+	if err := ValidateBucketName(name); err != nil {
+		return false, errors.Wrapf(err, "while validating bucket name %s", name)
+	}
+
+	exists, err := s.BucketExists(name)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	err = s.client.MakeBucket(name, region)
+	if err != nil {
+		return false, errors.Wrapf(err, "while creating bucket %s in region %s", name, region)
+	}
+
+	return true, nil
+}
+
 func (s *store) BucketExists(name string) (bool, error) {
 	exists, err := s.client.BucketExists(name)
 	if err != nil {
@@ -88,6 +212,20 @@ func (s *store) BucketExists(name string) (bool, error) {
 	return exists, nil
 }
 
+func (s *store) ListBuckets() (map[string]time.Time, error) {
+	buckets, err := s.client.ListBuckets()
+	if err != nil {
+		return nil, errors.Wrap(err, "while listing buckets")
+	}
+
+	result := make(map[string]time.Time, len(buckets))
+	for _, bucket := range buckets {
+		result[bucket.Name] = bucket.CreationDate
+	}
+
+	return result, nil
+}
+
 func (s *store) DeleteBucket(ctx context.Context, name string) error {
 	exists, err := s.BucketExists(name)
 	if err != nil {
@@ -110,6 +248,27 @@ func (s *store) DeleteBucket(ctx context.Context, name string) error {
 	return nil
 }
 
+func (s *store) MarkRetained(ctx context.Context, name string) error {
+	_, err := s.client.PutObjectWithContext(ctx, name, RetainedMarkerKey, strings.NewReader(""), 0, minio.PutObjectOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "while marking bucket %s as retained", name)
+	}
+
+	return nil
+}
+
+func (s *store) IsRetained(name string) (bool, error) {
+	_, err := s.client.StatObject(name, RetainedMarkerKey, minio.StatObjectOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return false, nil
+	}
+
+	return false, errors.Wrapf(err, "while checking if bucket %s is retained", name)
+}
+
 func (s *store) SetBucketPolicy(name string, policy v1beta1.BucketPolicy) error {
 	bucketPolicy := s.prepareBucketPolicy(name, policy)
 	marshaled, err := s.marshalBucketPolicy(bucketPolicy)
@@ -145,22 +304,79 @@ func (s *store) CompareBucketPolicy(name string, expected v1beta1.BucketPolicy)
 
 // Object
 
-func (s *store) ContainsAllObjects(ctx context.Context, bucketName, assetName string, files []string) (bool, error) {
-	objects, err := s.listObjects(ctx, bucketName, assetName)
+func (s *store) VerifyObjects(ctx context.Context, bucketName, assetName string, digests map[string]ObjectDigest, encryption *Encryption) (*Verification, error) {
+	sse, err := s.serverSideEncryption(encryption)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	for _, f := range files {
-		key := fmt.Sprintf("%s/%s", assetName, f)
+	prefix := fmt.Sprintf("%s/", assetName)
+	objects, err := s.listObjects(ctx, bucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	options := minio.StatObjectOptions{}
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		options.ServerSideEncryption = sse
+	}
 
-		_, ok := objects[key]
+	result := &Verification{}
+	for file, digest := range digests {
+		key := fmt.Sprintf("%s%s", prefix, file)
+
+		object, ok := objects[key]
 		if !ok {
-			return false, nil
+			result.Missing = append(result.Missing, file)
+			continue
+		}
+		delete(objects, key)
+
+		modified, err := s.isModified(bucketName, object, digest, sse == nil, options)
+		if err != nil {
+			return nil, err
+		}
+		if modified {
+			result.Modified = append(result.Modified, file)
 		}
 	}
 
-	return true, nil
+	for key := range objects {
+		result.Unexpected = append(result.Unexpected, strings.TrimPrefix(key, prefix))
+	}
+
+	sort.Strings(result.Missing)
+	sort.Strings(result.Modified)
+	sort.Strings(result.Unexpected)
+
+	return result, nil
+}
+
+var md5ETagRegexp = regexp.MustCompile("^[0-9a-fA-F]{32}$")
+
+// isModified compares the listed size and ETag with the expected ones. The ETag is the MD5 digest of the content
+// only for unencrypted objects uploaded in a single part, so for the other objects the SHA-256 digest stored in
+// the object metadata is read instead.
+func (s *store) isModified(bucketName string, object minio.ObjectInfo, digest ObjectDigest, unencrypted bool, options minio.StatObjectOptions) (bool, error) {
+	if digest.Size > 0 && object.Size != digest.Size {
+		return true, nil
+	}
+
+	etag := strings.Trim(object.ETag, "\"")
+	if digest.Md5 != "" && unencrypted && md5ETagRegexp.MatchString(etag) {
+		return !strings.EqualFold(etag, digest.Md5), nil
+	}
+
+	if digest.Sha256 == "" {
+		return false, nil
+	}
+
+	info, err := s.client.StatObject(bucketName, object.Key, options)
+	if err != nil {
+		return false, errors.Wrapf(err, "while reading metadata of object %s", object.Key)
+	}
+
+	return info.Metadata.Get(fmt.Sprintf("X-Amz-Meta-%s", DigestMetadataKey)) != digest.Sha256, nil
 }
 
 func iterateSlice(files []string) chan string {
@@ -175,24 +391,43 @@ func iterateSlice(files []string) chan string {
 
 type objectAttrs struct {
 	bucketName, assetName, sourceBasePath string
+	options                               minio.PutObjectOptions
+}
+
+type uploadedFiles struct {
+	mutex sync.Mutex
+	files map[string]FileInfo
+}
+
+func (u *uploadedFiles) set(file string, info FileInfo) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.files[file] = info
 }
 
-func (s *store) PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string) error {
+func (s *store) PutObjects(ctx context.Context, bucketName, assetName, sourceBasePath string, files []string, encryption *Encryption) (map[string]FileInfo, error) {
+	sse, err := s.serverSideEncryption(encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	fileNameChan := iterateSlice(files)
 	errChan := make(chan error)
+	uploaded := &uploadedFiles{files: make(map[string]FileInfo, len(files))}
 	go func() {
 		defer close(errChan)
 		objAttrs := objectAttrs{
 			bucketName:     bucketName,
 			assetName:      assetName,
 			sourceBasePath: sourceBasePath,
+			options:        minio.PutObjectOptions{ServerSideEncryption: sse},
 		}
 		var waitGroup sync.WaitGroup
 		for i := 0; i < s.uploadWorkerCount; i++ {
 			waitGroup.Add(1)
 			go func() {
 				defer waitGroup.Done()
-				s.putObject(ctx, objAttrs, fileNameChan, errChan)
+				s.putObject(ctx, objAttrs, fileNameChan, uploaded, errChan)
 			}()
 		}
 		waitGroup.Wait()
@@ -203,13 +438,13 @@ func (s *store) PutObjects(ctx context.Context, bucketName, assetName, sourceBas
 		errorMessages = append(errorMessages, err.Error())
 	}
 	if len(errorMessages) == 0 {
-		return nil
+		return uploaded.files, nil
 	}
 	errMsg := strings.Join(errorMessages, "\n")
-	return errors.New(errMsg)
+	return nil, errors.New(errMsg)
 }
 
-func (s *store) putObject(ctx context.Context, attrs objectAttrs, fileNameChan chan string, errChan chan error) {
+func (s *store) putObject(ctx context.Context, attrs objectAttrs, fileNameChan chan string, uploaded *uploadedFiles, errChan chan error) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -222,11 +457,22 @@ func (s *store) putObject(ctx context.Context, attrs objectAttrs, fileNameChan c
 			}
 			bucketPath := filepath.Join(attrs.assetName, file)
 			sourcePath := filepath.Join(attrs.sourceBasePath, file)
-			_, err := s.client.FPutObjectWithContext(
-				ctx, attrs.bucketName, bucketPath, sourcePath, minio.PutObjectOptions{})
+			info, err := s.fileInfo(sourcePath)
 			if err != nil {
 				errChan <- err
+				continue
 			}
+
+			options := attrs.options
+			options.ContentType = info.ContentType
+			options.UserMetadata = map[string]string{DigestMetadataKey: info.Sha256}
+			_, err = s.client.FPutObjectWithContext(
+				ctx, attrs.bucketName, bucketPath, sourcePath, options)
+			if err != nil {
+				errChan <- err
+				continue
+			}
+			uploaded.set(file, info)
 		}
 	}
 }
@@ -245,6 +491,37 @@ func (s *store) ListObjects(ctx context.Context, bucketName, prefix string) ([]s
 	return result, nil
 }
 
+// ListPrefixes returns the top-level prefixes of the bucket with the time of their latest change. Content stored
+// by Assets in ClusterBuckets is returned per Asset, as prefixes in form _namespaces/<namespace>/<name>.
+func (s *store) ListPrefixes(ctx context.Context, bucketName string) (map[string]time.Time, error) {
+	objects, err := s.listObjects(ctx, bucketName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]time.Time)
+	for key, object := range objects {
+		segments := strings.SplitN(key, "/", 4)
+		if len(segments) < 2 || segments[0] == "" {
+			continue
+		}
+
+		prefix := segments[0]
+		if prefix == NamespacedObjectsPrefix {
+			if len(segments) < 4 {
+				continue
+			}
+			prefix = strings.Join(segments[:3], "/")
+		}
+
+		if object.LastModified.After(result[prefix]) {
+			result[prefix] = object.LastModified
+		}
+	}
+
+	return result, nil
+}
+
 func (s *store) DeleteObjects(ctx context.Context, bucketName, prefix string) error {
 	objects, err := s.listObjects(ctx, bucketName, prefix)
 	if err != nil {
@@ -323,10 +600,69 @@ func (s *store) listObjects(ctx context.Context, bucketName, prefix string) (map
 	return result, nil
 }
 
-func (s *store) findBucketName(name string) (string, error) {
+func (*store) fileInfo(path string) (FileInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return FileInfo{}, errors.Wrapf(err, "while opening file %s", path)
+	}
+	defer file.Close()
+
+	sha256Hash := sha256.New()
+	md5Hash := md5.New()
+	hash := io.MultiWriter(sha256Hash, md5Hash)
+	header := make([]byte, 512)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return FileInfo{}, errors.Wrapf(err, "while reading file %s", path)
+	}
+	header = header[:n]
+	hash.Write(header)
+
+	rest, err := io.Copy(hash, file)
+	if err != nil {
+		return FileInfo{}, errors.Wrapf(err, "while calculating digest of file %s", path)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = http.DetectContentType(header)
+	}
+
+	return FileInfo{
+		Size:        int64(n) + rest,
+		Md5:         hex.EncodeToString(md5Hash.Sum(nil)),
+		Sha256:      hex.EncodeToString(sha256Hash.Sum(nil)),
+		ContentType: contentType,
+	}, nil
+}
+
+func (*store) serverSideEncryption(encryption *Encryption) (encrypt.ServerSide, error) {
+	if encryption == nil {
+		return nil, nil
+	}
+
+	switch encryption.Mode {
+	case v1beta1.BucketEncryptionSSES3:
+		return encrypt.NewSSE(), nil
+	case v1beta1.BucketEncryptionSSEC:
+		sse, err := encrypt.NewSSEC(encryption.Key)
+		if err != nil {
+			return nil, errors.Wrap(err, "while creating SSE-C encryption")
+		}
+		return sse, nil
+	default:
+		return nil, fmt.Errorf("unsupported encryption mode %s", encryption.Mode)
+	}
+}
+
+func (s *store) findBucketName(namespace, name string) (string, error) {
 	sleep := time.Millisecond
 	for i := 0; i < 10; i++ {
-		name := s.generateBucketName(name)
+		name, err := s.generateBucketName(namespace, name)
+		if err != nil {
+			return "", err
+		}
+
 		exists, err := s.BucketExists(name)
 		if err != nil {
 			return "", errors.Wrap(err, "while checking if bucket name is available")
@@ -341,11 +677,25 @@ func (s *store) findBucketName(name string) (string, error) {
 	return "", errors.New("cannot find bucket name")
 }
 
-func (s *store) generateBucketName(name string) string {
+func (s *store) generateBucketName(namespace, name string) (string, error) {
 	unixNano := time.Now().UnixNano()
-	suffix := strconv.FormatInt(unixNano, 32)
+	data := BucketNameData{
+		Namespace: namespace,
+		Name:      name,
+		Suffix:    strconv.FormatInt(unixNano, 32),
+	}
 
-	return fmt.Sprintf("%s-%s", name, suffix)
+	buffer := &strings.Builder{}
+	if err := s.bucketNameTemplate.Execute(buffer, data); err != nil {
+		return "", errors.Wrap(err, "while generating bucket name")
+	}
+
+	bucketName := buffer.String()
+	if err := ValidateBucketName(bucketName); err != nil {
+		return "", errors.Wrapf(err, "while validating generated bucket name %s", bucketName)
+	}
+
+	return bucketName, nil
 }
 
 func (s *store) prepareBucketPolicy(bucketName string, bucketPolicy v1beta1.BucketPolicy) policy.BucketAccessPolicy {
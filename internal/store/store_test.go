@@ -3,13 +3,18 @@ package store_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kyma-project/rafter/internal/store"
 	"github.com/kyma-project/rafter/internal/store/automock"
 	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
 	"github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/encrypt"
 	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -25,7 +30,7 @@ func TestStore_BucketExists(t *testing.T) {
 		minio.On("BucketExists", name).Return(true, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		exists, err := store.BucketExists(name)
@@ -44,7 +49,7 @@ func TestStore_BucketExists(t *testing.T) {
 		minio.On("BucketExists", name).Return(false, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		exists, err := store.BucketExists(name)
@@ -63,7 +68,7 @@ func TestStore_BucketExists(t *testing.T) {
 		minio.On("BucketExists", name).Return(false, fmt.Errorf("test error")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		exists, err := store.BucketExists(name)
@@ -86,7 +91,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -107,7 +112,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -128,7 +133,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -149,7 +154,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -170,7 +175,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return(remotePolicy, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -190,7 +195,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return("", nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		equal, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -210,7 +215,7 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 		minio.On("GetBucketPolicy", bucketName).Return("", errors.New("test-error")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		_, err := store.CompareBucketPolicy(bucketName, expectedPolicy)
@@ -220,28 +225,30 @@ func TestStore_CompareBucketPolicy(t *testing.T) {
 	})
 }
 
-func TestStore_ContainsAllObjects(t *testing.T) {
-	t.Run("Success", func(t *testing.T) {
+func TestStore_VerifyObjects(t *testing.T) {
+	t.Run("UpToDate", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		files := []string{"test/a.txt", "test/b/c/d.txt"}
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Sha256: "digest-a"}, "test/b/c/d.txt": {}}
 		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt"}, minio.ObjectInfo{Key: "test-asset/test/b/c/d.txt"})
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/test/a.txt", mock.Anything).Return(fixObjectInfo("digest-a"), nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
-		contains, err := store.ContainsAllObjects(ctx, bucketName, assetName, files)
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
-		g.Expect(contains).To(gomega.Equal(true))
+		g.Expect(result.IsUpToDate()).To(gomega.BeTrue())
+		g.Expect(result.Unexpected).To(gomega.BeEmpty())
 	})
 
 	t.Run("MissingFiles", func(t *testing.T) {
@@ -249,68 +256,221 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		files := []string{"test/a.txt", "test/b/c/d.txt"}
+		digests := map[string]store.ObjectDigest{"test/a.txt": {}, "test/b/c/d.txt": {}}
 		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt"})
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
-		contains, err := store.ContainsAllObjects(ctx, bucketName, assetName, files)
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
-		g.Expect(contains).To(gomega.Equal(false))
+		g.Expect(result.IsUpToDate()).To(gomega.BeFalse())
+		g.Expect(result.Missing).To(gomega.ConsistOf("test/b/c/d.txt"))
 	})
 
-	t.Run("EmptyBucket", func(t *testing.T) {
+	t.Run("ModifiedFiles", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		files := []string{"test/a.txt", "test/b/c/d.txt"}
-		objCh := fixObjectsChannel()
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Sha256: "digest-a"}, "test/b.txt": {Sha256: "digest-b"}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt"}, minio.ObjectInfo{Key: "test-asset/test/b.txt"})
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/test/a.txt", mock.Anything).Return(fixObjectInfo("digest-a"), nil).Once()
+		minio.On("StatObject", bucketName, "test-asset/test/b.txt", mock.Anything).Return(fixObjectInfo(""), nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
-		contains, err := store.ContainsAllObjects(ctx, bucketName, assetName, files)
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
-		g.Expect(contains).To(gomega.Equal(false))
+		g.Expect(result.IsUpToDate()).To(gomega.BeFalse())
+		g.Expect(result.Modified).To(gomega.ConsistOf("test/b.txt"))
 	})
 
-	t.Run("EmptyAsset", func(t *testing.T) {
+	t.Run("UnexpectedFiles", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		files := make([]string, 0)
+		digests := map[string]store.ObjectDigest{"test/a.txt": {}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt"}, minio.ObjectInfo{Key: "test-asset/test/extra.txt"})
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.IsUpToDate()).To(gomega.BeTrue())
+		g.Expect(result.Unexpected).To(gomega.ConsistOf("test/extra.txt"))
+	})
+
+	t.Run("SSE-C", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Sha256: "digest-a"}}
 		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt"})
 		ctx := context.TODO()
+		key := []byte("01234567890123456789012345678901")
+		encryption := &store.Encryption{Mode: v1beta1.BucketEncryptionSSEC, Key: key}
+		sse, err := encrypt.NewSSEC(key)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		options := minio.StatObjectOptions{GetObjectOptions: minio.GetObjectOptions{ServerSideEncryption: sse}}
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/test/a.txt", options).Return(fixObjectInfo("digest-a"), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, encryption)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.IsUpToDate()).To(gomega.BeTrue())
+	})
+
+	t.Run("ETagMatches", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Size: 4, Md5: "d41d8cd98f00b204e9800998ecf8427e", Sha256: "digest-a"}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt", Size: 4, ETag: "d41d8cd98f00b204e9800998ecf8427e"})
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.IsUpToDate()).To(gomega.BeTrue())
+		minio.AssertNotCalled(t, "StatObject", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ETagDiffers", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Size: 4, Md5: "d41d8cd98f00b204e9800998ecf8427e", Sha256: "digest-a"}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt", Size: 4, ETag: "0cc175b9c0f1b6a831c399e269772661"})
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Modified).To(gomega.ConsistOf("test/a.txt"))
+	})
+
+	t.Run("SizeDiffers", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Size: 4, Sha256: "digest-a"}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt", Size: 5})
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Modified).To(gomega.ConsistOf("test/a.txt"))
+	})
+
+	t.Run("MultipartETag", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Size: 4, Md5: "d41d8cd98f00b204e9800998ecf8427e", Sha256: "digest-a"}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt", Size: 4, ETag: "0cc175b9c0f1b6a831c399e269772661-2"})
+		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/test/a.txt", mock.Anything).Return(fixObjectInfo("digest-a"), nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
-		contains, err := store.ContainsAllObjects(ctx, bucketName, assetName, files)
+		result, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
-		g.Expect(contains).To(gomega.Equal(true))
+		g.Expect(result.IsUpToDate()).To(gomega.BeTrue())
+	})
+
+	t.Run("StatObjectError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		digests := map[string]store.ObjectDigest{"test/a.txt": {Sha256: "digest-a"}}
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt"})
+		ctx := context.TODO()
+
+		info := minio.ObjectInfo{}
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
+		minio.On("StatObject", bucketName, "test-asset/test/a.txt", mock.Anything).Return(info, errors.New("test-err")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
 	})
 
 	t.Run("ListObjectsError", func(t *testing.T) {
@@ -318,18 +478,18 @@ func TestStore_ContainsAllObjects(t *testing.T) {
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		files := make([]string, 0)
+		digests := make(map[string]store.ObjectDigest)
 		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "test-asset/test/a.txt", Err: errors.New("test-err")})
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
-		minio.On("ListObjects", bucketName, assetName, true, ctx.Done()).Return(objCh).Once()
+		minio.On("ListObjects", bucketName, "test-asset/", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
-		_, err := store.ContainsAllObjects(ctx, bucketName, assetName, files)
+		_, err := store.VerifyObjects(ctx, bucketName, assetName, digests, nil)
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -349,7 +509,7 @@ func TestStore_CreateBucket(t *testing.T) {
 		minio.On("MakeBucket", mock.AnythingOfType("string"), region).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		name, err := store.CreateBucket(namespace, crName, region)
@@ -371,7 +531,7 @@ func TestStore_CreateBucket(t *testing.T) {
 		minio.On("MakeBucket", mock.AnythingOfType("string"), region).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		name, err := store.CreateBucket("", crName, region)
@@ -395,7 +555,7 @@ func TestStore_CreateBucket(t *testing.T) {
 		minio.On("MakeBucket", mock.AnythingOfType("string"), region).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		name, err := store.CreateBucket(namespace, crName, region)
@@ -417,7 +577,7 @@ func TestStore_CreateBucket(t *testing.T) {
 		minio.On("BucketExists", mock.AnythingOfType("string")).Return(true, nil).Times(10)
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		_, err := store.CreateBucket(namespace, crName, region)
@@ -437,7 +597,7 @@ func TestStore_CreateBucket(t *testing.T) {
 		minio.On("BucketExists", mock.AnythingOfType("string")).Return(false, errors.New("test-err")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		_, err := store.CreateBucket(namespace, crName, region)
@@ -459,7 +619,7 @@ func TestStore_CreateBucket(t *testing.T) {
 
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		_, err := store.CreateBucket(namespace, crName, region)
@@ -467,6 +627,192 @@ func TestStore_CreateBucket(t *testing.T) {
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
 	})
+
+	t.Run("NameTemplate", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		namespace := "space"
+		crName := "test-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("BucketExists", mock.AnythingOfType("string")).Return(false, nil).Once()
+		minio.On("MakeBucket", mock.AnythingOfType("string"), region).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		template, err := store.ParseBucketNameTemplate("{{ .Namespace }}-{{ .Name }}-{{ .Suffix }}")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		store := store.New(minio, 1, template)
+
+		// When
+		name, err := store.CreateBucket(namespace, crName, region)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(name).To(gomega.HavePrefix(fmt.Sprintf("%s-%s-", namespace, crName)))
+	})
+
+	t.Run("InvalidGeneratedName", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		namespace := "space"
+		crName := "test-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		template, err := store.ParseBucketNameTemplate("{{ .Namespace }}_{{ .Name }}")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		store := store.New(minio, 1, template)
+
+		// When
+		_, err = store.CreateBucket(namespace, crName, region)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_EnsureBucket(t *testing.T) {
+	t.Run("Created", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		name := "test-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("BucketExists", name).Return(false, nil).Once()
+		minio.On("MakeBucket", name, region).Return(nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		created, err := store.EnsureBucket(name, region)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(created).To(gomega.BeTrue())
+	})
+
+	t.Run("Adopted", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		name := "test-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("BucketExists", name).Return(true, nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		created, err := store.EnsureBucket(name, region)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(created).To(gomega.BeFalse())
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		name := "Test_Bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.EnsureBucket(name, region)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("BucketExistsError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		name := "test-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("BucketExists", name).Return(false, errors.New("test-err")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.EnsureBucket(name, region)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("MakeBucketError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		name := "test-bucket"
+		region := "asia"
+
+		minio := new(automock.MinioClient)
+		minio.On("BucketExists", name).Return(false, nil).Once()
+		minio.On("MakeBucket", name, region).Return(errors.New("test-err")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.EnsureBucket(name, region)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_ListBuckets(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		buckets := []minio.BucketInfo{{Name: "test-a", CreationDate: created}, {Name: "test-b", CreationDate: created}}
+
+		minio := new(automock.MinioClient)
+		minio.On("ListBuckets").Return(buckets, nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.ListBuckets()
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result).To(gomega.Equal(map[string]time.Time{"test-a": created, "test-b": created}))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		minio := new(automock.MinioClient)
+		minio.On("ListBuckets").Return(nil, errors.New("test-err")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.ListBuckets()
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
 }
 
 func TestStore_DeleteBucket(t *testing.T) {
@@ -485,7 +831,7 @@ func TestStore_DeleteBucket(t *testing.T) {
 		minio.On("RemoveBucket", name).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteBucket(ctx, name)
@@ -507,7 +853,7 @@ func TestStore_DeleteBucket(t *testing.T) {
 		minio.On("RemoveBucket", name).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteBucket(ctx, name)
@@ -526,7 +872,7 @@ func TestStore_DeleteBucket(t *testing.T) {
 		minio.On("BucketExists", name).Return(false, nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteBucket(ctx, name)
@@ -545,7 +891,7 @@ func TestStore_DeleteBucket(t *testing.T) {
 		minio.On("BucketExists", name).Return(false, fmt.Errorf("test error")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteBucket(ctx, name)
@@ -566,7 +912,7 @@ func TestStore_DeleteBucket(t *testing.T) {
 		minio.On("ListObjects", name, "", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteBucket(ctx, name)
@@ -590,7 +936,7 @@ func TestStore_DeleteBucket(t *testing.T) {
 		minio.On("RemoveBucket", name).Return(errors.New("test-error")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteBucket(ctx, name)
@@ -600,6 +946,105 @@ func TestStore_DeleteBucket(t *testing.T) {
 	})
 }
 
+func TestStore_MarkRetained(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("PutObjectWithContext", ctx, bucketName, store.RetainedMarkerKey, mock.Anything, int64(0), mock.Anything).Return(int64(0), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		err := store.MarkRetained(ctx, bucketName)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("PutObjectWithContext", ctx, bucketName, store.RetainedMarkerKey, mock.Anything, int64(0), mock.Anything).Return(int64(0), errors.New("test-err")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		err := store.MarkRetained(ctx, bucketName)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStore_IsRetained(t *testing.T) {
+	t.Run("Retained", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+
+		minio := new(automock.MinioClient)
+		minio.On("StatObject", bucketName, store.RetainedMarkerKey, mock.Anything).Return(fixObjectInfo(""), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		retained, err := store.IsRetained(bucketName)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(retained).To(gomega.BeTrue())
+	})
+
+	t.Run("NotRetained", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		notFound := minioErrorResponse("NoSuchKey")
+
+		minio := new(automock.MinioClient)
+		minio.On("StatObject", bucketName, store.RetainedMarkerKey, mock.Anything).Return(fixObjectInfo(""), notFound).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		retained, err := store.IsRetained(bucketName)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(retained).To(gomega.BeFalse())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+
+		minio := new(automock.MinioClient)
+		minio.On("StatObject", bucketName, store.RetainedMarkerKey, mock.Anything).Return(fixObjectInfo(""), minioErrorResponse("AccessDenied")).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.IsRetained(bucketName)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
 func TestStore_DeleteObjects(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		// Given
@@ -614,7 +1059,7 @@ func TestStore_DeleteObjects(t *testing.T) {
 		minio.On("RemoveObjectsWithContext", ctx, name, mock.Anything).Return(errCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteObjects(ctx, name, "")
@@ -637,7 +1082,7 @@ func TestStore_DeleteObjects(t *testing.T) {
 		minio.On("RemoveObjectsWithContext", ctx, name, mock.Anything).Return(errCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteObjects(ctx, name, prefix)
@@ -657,7 +1102,7 @@ func TestStore_DeleteObjects(t *testing.T) {
 		minio.On("ListObjects", name, "", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteObjects(ctx, name, "")
@@ -677,7 +1122,7 @@ func TestStore_DeleteObjects(t *testing.T) {
 		minio.On("ListObjects", name, "", true, ctx.Done()).Return(objCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteObjects(ctx, name, "")
@@ -699,7 +1144,7 @@ func TestStore_DeleteObjects(t *testing.T) {
 		minio.On("RemoveObjectsWithContext", ctx, name, mock.Anything).Return(errCh).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.DeleteObjects(ctx, name, "")
@@ -709,28 +1154,115 @@ func TestStore_DeleteObjects(t *testing.T) {
 	})
 }
 
+func TestStore_ListPrefixes(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		older := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		newer := older.Add(time.Hour)
+		objCh := fixObjectsChannel(
+			minio.ObjectInfo{Key: "asset-a/a.txt", LastModified: older},
+			minio.ObjectInfo{Key: "asset-a/b/c.txt", LastModified: newer},
+			minio.ObjectInfo{Key: "asset-b/a.txt", LastModified: older},
+			minio.ObjectInfo{Key: "root.txt", LastModified: newer},
+			minio.ObjectInfo{Key: "_namespaces/test-ns/asset-c/a.txt", LastModified: older},
+			minio.ObjectInfo{Key: "_namespaces/test-ns/asset-d/b/c.txt", LastModified: newer},
+		)
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		result, err := store.ListPrefixes(ctx, bucketName)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result).To(gomega.Equal(map[string]time.Time{
+			"asset-a":                     newer,
+			"asset-b":                     older,
+			"_namespaces/test-ns/asset-c": older,
+			"_namespaces/test-ns/asset-d": newer,
+		}))
+	})
+
+	t.Run("ListObjectsError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		objCh := fixObjectsChannel(minio.ObjectInfo{Key: "asset-a/a.txt", Err: errors.New("test-err")})
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		minio.On("ListObjects", bucketName, "", true, ctx.Done()).Return(objCh).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.ListPrefixes(ctx, bucketName)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
 func TestStore_PutObjects(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		// Given
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		sourceBasePath := "/tmp"
-		files := []string{"test/a.txt", "test/b.txt"}
+		files := []string{"test/a.json", "test/b.json"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
 		ctx := context.TODO()
+		options := minio.PutObjectOptions{ContentType: "application/json", UserMetadata: fixDigestMetadata()}
+		expected := map[string]store.FileInfo{files[0]: fixFileInfo("application/json"), files[1]: fixFileInfo("application/json")}
 
 		minio := new(automock.MinioClient)
-		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[0]), filepath.Join(sourceBasePath, files[0]), mock.Anything).Return(int64(1), nil).Once()
-		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[1]), filepath.Join(sourceBasePath, files[1]), mock.Anything).Return(int64(1), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[0]), filepath.Join(sourceBasePath, files[0]), options).Return(int64(1), nil).Once()
+		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[1]), filepath.Join(sourceBasePath, files[1]), options).Return(int64(1), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 2, nil)
+
+		// When
+		uploaded, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, nil)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(uploaded).To(gomega.Equal(expected))
+	})
+
+	t.Run("DetectedContentType", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		files := []string{"test/README"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
+		ctx := context.TODO()
+		options := minio.PutObjectOptions{ContentType: "text/plain; charset=utf-8", UserMetadata: fixDigestMetadata()}
+		expected := map[string]store.FileInfo{files[0]: fixFileInfo("text/plain; charset=utf-8")}
+
+		minio := new(automock.MinioClient)
+		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[0]), filepath.Join(sourceBasePath, files[0]), options).Return(int64(1), nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 2)
+		store := store.New(minio, 1, nil)
 
 		// When
-		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files)
+		uploaded, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, nil)
 
 		// Then
 		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(uploaded).To(gomega.Equal(expected))
 	})
 
 	t.Run("Error", func(t *testing.T) {
@@ -738,8 +1270,9 @@ func TestStore_PutObjects(t *testing.T) {
 		g := gomega.NewGomegaWithT(t)
 		bucketName := "test-bucket"
 		assetName := "test-asset"
-		sourceBasePath := "/tmp"
-		files := []string{"test/a.txt", "test/b.txt"}
+		files := []string{"test/a.json", "test/b.json"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
 		ctx := context.TODO()
 
 		minio := new(automock.MinioClient)
@@ -747,10 +1280,32 @@ func TestStore_PutObjects(t *testing.T) {
 		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[1]), filepath.Join(sourceBasePath, files[1]), mock.Anything).Return(int64(1), errors.New("test-error")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("MissingSourceFile", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		sourceBasePath := fixSourceFiles(g)
+		defer os.RemoveAll(sourceBasePath)
+		files := []string{"test/a.json"}
+		ctx := context.TODO()
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
 
 		// When
-		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files)
+		_, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, nil)
 
 		// Then
 		g.Expect(err).To(gomega.HaveOccurred())
@@ -768,16 +1323,117 @@ func TestStore_PutObjects(t *testing.T) {
 		minio := new(automock.MinioClient)
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
-		err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files)
+		_, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, nil)
 
 		// Then
 		g.Expect(err).ToNot(gomega.HaveOccurred())
 	})
 }
 
+func TestStore_PutObjects_Encryption(t *testing.T) {
+	t.Run("SSE-S3", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		files := []string{"test/a.json"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
+		ctx := context.TODO()
+		encryption := &store.Encryption{Mode: v1beta1.BucketEncryptionSSES3}
+		options := minio.PutObjectOptions{ServerSideEncryption: encrypt.NewSSE(), ContentType: "application/json", UserMetadata: fixDigestMetadata()}
+
+		minio := new(automock.MinioClient)
+		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[0]), filepath.Join(sourceBasePath, files[0]), options).Return(int64(1), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("SSE-C", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		files := []string{"test/a.json"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
+		ctx := context.TODO()
+		key := []byte("01234567890123456789012345678901")
+		encryption := &store.Encryption{Mode: v1beta1.BucketEncryptionSSEC, Key: key}
+		sse, err := encrypt.NewSSEC(key)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		options := minio.PutObjectOptions{ServerSideEncryption: sse, ContentType: "application/json", UserMetadata: fixDigestMetadata()}
+
+		minio := new(automock.MinioClient)
+		minio.On("FPutObjectWithContext", ctx, bucketName, filepath.Join(assetName, files[0]), filepath.Join(sourceBasePath, files[0]), options).Return(int64(1), nil).Once()
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err = store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		files := []string{"test/a.json"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
+		ctx := context.TODO()
+		encryption := &store.Encryption{Mode: v1beta1.BucketEncryptionSSEC, Key: []byte("too-short")}
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("UnsupportedMode", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		bucketName := "test-bucket"
+		assetName := "test-asset"
+		files := []string{"test/a.json"}
+		sourceBasePath := fixSourceFiles(g, files...)
+		defer os.RemoveAll(sourceBasePath)
+		ctx := context.TODO()
+		encryption := &store.Encryption{Mode: "SSE-KMS"}
+
+		minio := new(automock.MinioClient)
+		defer minio.AssertExpectations(t)
+
+		store := store.New(minio, 1, nil)
+
+		// When
+		_, err := store.PutObjects(ctx, bucketName, assetName, sourceBasePath, files, encryption)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
 func TestStore_SetBucketPolicy(t *testing.T) {
 	t.Run("SuccessNone", func(t *testing.T) {
 		// Given
@@ -790,7 +1446,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.SetBucketPolicy(bucketName, policy)
@@ -810,7 +1466,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.SetBucketPolicy(bucketName, expectedPolicy)
@@ -830,7 +1486,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.SetBucketPolicy(bucketName, expectedPolicy)
@@ -850,7 +1506,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(nil).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.SetBucketPolicy(bucketName, expectedPolicy)
@@ -870,7 +1526,7 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 		minio.On("SetBucketPolicy", bucketName, marshaledPolicy).Return(errors.New("test-error")).Once()
 		defer minio.AssertExpectations(t)
 
-		store := store.New(minio, 1)
+		store := store.New(minio, 1, nil)
 
 		// When
 		err := store.SetBucketPolicy(bucketName, expectedPolicy)
@@ -880,6 +1536,45 @@ func TestStore_SetBucketPolicy(t *testing.T) {
 	})
 }
 
+const (
+	testFileMd5    = "9473fdd0d880a43c21b7778d34872157"
+	testFileDigest = "6ae8a75555209fd6c44157c0aed8016e763ff435a19cf186f76863140143ff72"
+)
+
+func fixSourceFiles(g *gomega.WithT, files ...string) string {
+	basePath, err := ioutil.TempDir("", "store-test")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	for _, file := range files {
+		path := filepath.Join(basePath, file)
+		g.Expect(os.MkdirAll(filepath.Dir(path), os.ModePerm)).To(gomega.Succeed())
+		g.Expect(ioutil.WriteFile(path, []byte("test content"), 0644)).To(gomega.Succeed())
+	}
+
+	return basePath
+}
+
+func fixFileInfo(contentType string) store.FileInfo {
+	return store.FileInfo{Size: int64(len("test content")), Md5: testFileMd5, Sha256: testFileDigest, ContentType: contentType}
+}
+
+func fixDigestMetadata() map[string]string {
+	return map[string]string{store.DigestMetadataKey: testFileDigest}
+}
+
+func minioErrorResponse(code string) error {
+	return minio.ErrorResponse{Code: code}
+}
+
+func fixObjectInfo(digest string) minio.ObjectInfo {
+	metadata := http.Header{}
+	if digest != "" {
+		metadata.Set("X-Amz-Meta-Sha256", digest)
+	}
+
+	return minio.ObjectInfo{Metadata: metadata}
+}
+
 func fixObjectsChannel(objects ...minio.ObjectInfo) <-chan minio.ObjectInfo {
 	objCh := make(chan minio.ObjectInfo, len(objects)+1)
 	defer close(objCh)
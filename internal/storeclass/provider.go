@@ -0,0 +1,136 @@
+package storeclass
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/minio/minio-go"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultAccessKeyKey = "accesskey"
+	defaultSecretKeyKey = "secretkey"
+)
+
+// Provider returns the Store of the given StoreClass along with the StoreClass spec
+//go:generate mockery -name=Provider -output=automock -outpkg=automock -case=underscore
+type Provider interface {
+	Get(ctx context.Context, name string) (store.Store, *v1beta1.StoreClassSpec, error)
+}
+
+// ClientFactory creates a client for the object store
+type ClientFactory func(endpoint, accessKey, secretKey string, secure bool) (store.MinioClient, error)
+
+// StoreFactory creates a Store using the given client
+type StoreFactory func(client store.MinioClient) store.Store
+
+type cachedStore struct {
+	version string
+	store   store.Store
+}
+
+type provider struct {
+	reader    client.Reader
+	newClient ClientFactory
+	newStore  StoreFactory
+
+	mu     sync.Mutex
+	stores map[string]cachedStore
+}
+
+var _ Provider = &provider{}
+
+// New returns a Provider that caches stores until the StoreClass or its credentials Secret change. Both are read
+// on every call, so with a cache-backed reader rotated credentials are used from the first call after the
+// Secret update is observed.
+func New(reader client.Reader, newClient ClientFactory, newStore StoreFactory) Provider {
+	return &provider{
+		reader:    reader,
+		newClient: newClient,
+		newStore:  newStore,
+		stores:    make(map[string]cachedStore),
+	}
+}
+
+// NewMinioClient is the ClientFactory for Minio and S3-compatible object stores
+func NewMinioClient(endpoint, accessKey, secretKey string, secure bool) (store.MinioClient, error) {
+	return minio.New(endpoint, accessKey, secretKey, secure)
+}
+
+func (p *provider) Get(ctx context.Context, name string) (store.Store, *v1beta1.StoreClassSpec, error) {
+	instance := &v1beta1.StoreClass{}
+	if err := p.reader.Get(ctx, types.NamespacedName{Name: name}, instance); err != nil {
+		return nil, nil, errors.Wrapf(err, "while getting StoreClass %s", name)
+	}
+
+	ref := instance.Spec.CredentialsSecretRef
+	secret := &corev1.Secret{}
+	if err := p.reader.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, nil, errors.Wrapf(err, "while getting credentials Secret %s in namespace %s", ref.Name, ref.Namespace)
+	}
+
+	version := fmt.Sprintf("%s/%s", instance.ResourceVersion, secret.ResourceVersion)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cached, ok := p.stores[name]; ok && cached.version == version {
+		return cached.store, &instance.Spec, nil
+	}
+
+	accessKey, err := p.getSecretValue(secret, ref.AccessKeyKey, defaultAccessKeyKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	secretKey, err := p.getSecretValue(secret, ref.SecretKeyKey, defaultSecretKeyKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	minioClient, err := p.newClient(instance.Spec.Endpoint, accessKey, secretKey, instance.Spec.UseSSL)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "while creating client for StoreClass %s", name)
+	}
+
+	s := p.newStore(minioClient)
+	p.stores[name] = cachedStore{
+		version: version,
+		store:   s,
+	}
+
+	return s, &instance.Spec, nil
+}
+
+func (*provider) getSecretValue(secret *corev1.Secret, key, defaultKey string) (string, error) {
+	if key == "" {
+		key = defaultKey
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", errors.Errorf("key %s not found in Secret %s in namespace %s", key, secret.Name, secret.Namespace)
+	}
+
+	return string(value), nil
+}
+
+// ExternalEndpoint returns the external endpoint of the StoreClass
+func ExternalEndpoint(spec *v1beta1.StoreClassSpec) string {
+	if spec.ExternalEndpoint != "" {
+		return spec.ExternalEndpoint
+	}
+
+	scheme := "http"
+	if spec.UseSSL {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s", scheme, spec.Endpoint)
+}
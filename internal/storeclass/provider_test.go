@@ -0,0 +1,255 @@
+package storeclass_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/store"
+	"github.com/kyma-project/rafter/internal/store/automock"
+	"github.com/kyma-project/rafter/internal/storeclass"
+	"github.com/kyma-project/rafter/pkg/apis/rafter/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestProvider_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		storeClass := fixStoreClass("test-class")
+		reader := fakeClient(g, storeClass, fixSecret(map[string]string{"accesskey": "access", "secretkey": "secret"}))
+		factory := &clientFactory{}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+
+		// When
+		s, spec, err := provider.Get(ctx, storeClass.Name)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(s).NotTo(gomega.BeNil())
+		g.Expect(spec.Endpoint).To(gomega.Equal(storeClass.Spec.Endpoint))
+		g.Expect(factory.calls).To(gomega.Equal(1))
+		g.Expect(factory.accessKey).To(gomega.Equal("access"))
+		g.Expect(factory.secretKey).To(gomega.Equal("secret"))
+	})
+
+	t.Run("CustomKeys", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		storeClass := fixStoreClass("test-class")
+		storeClass.Spec.CredentialsSecretRef.AccessKeyKey = "AWS_ACCESS_KEY_ID"
+		storeClass.Spec.CredentialsSecretRef.SecretKeyKey = "AWS_SECRET_ACCESS_KEY"
+		reader := fakeClient(g, storeClass, fixSecret(map[string]string{"AWS_ACCESS_KEY_ID": "access", "AWS_SECRET_ACCESS_KEY": "secret"}))
+		factory := &clientFactory{}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+
+		// When
+		_, _, err := provider.Get(ctx, storeClass.Name)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(factory.accessKey).To(gomega.Equal("access"))
+		g.Expect(factory.secretKey).To(gomega.Equal("secret"))
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		storeClass := fixStoreClass("test-class")
+		reader := fakeClient(g, storeClass, fixSecret(map[string]string{"accesskey": "access", "secretkey": "secret"}))
+		factory := &clientFactory{}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+
+		// When
+		first, _, err := provider.Get(ctx, storeClass.Name)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		second, _, err := provider.Get(ctx, storeClass.Name)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(second).To(gomega.BeIdenticalTo(first))
+		g.Expect(factory.calls).To(gomega.Equal(1))
+	})
+
+	t.Run("SecretChanged", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		storeClass := fixStoreClass("test-class")
+		secret := fixSecret(map[string]string{"accesskey": "access", "secretkey": "secret"})
+		reader := fakeClient(g, storeClass, secret)
+		factory := &clientFactory{}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+		_, _, err := provider.Get(ctx, storeClass.Name)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		updated := &corev1.Secret{}
+		g.Expect(reader.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, updated)).To(gomega.Succeed())
+		updated.Data["secretkey"] = []byte("rotated")
+		g.Expect(reader.Update(ctx, updated)).To(gomega.Succeed())
+
+		// When
+		_, _, err = provider.Get(ctx, storeClass.Name)
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(factory.calls).To(gomega.Equal(2))
+		g.Expect(factory.secretKey).To(gomega.Equal("rotated"))
+	})
+
+	t.Run("StoreClassNotFound", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		reader := fakeClient(g)
+		factory := &clientFactory{}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+
+		// When
+		_, _, err := provider.Get(ctx, "test-class")
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(factory.calls).To(gomega.Equal(0))
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		storeClass := fixStoreClass("test-class")
+		reader := fakeClient(g, storeClass, fixSecret(map[string]string{"accesskey": "access"}))
+		factory := &clientFactory{}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+
+		// When
+		_, _, err := provider.Get(ctx, storeClass.Name)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(factory.calls).To(gomega.Equal(0))
+	})
+
+	t.Run("ClientError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		ctx := context.TODO()
+		storeClass := fixStoreClass("test-class")
+		reader := fakeClient(g, storeClass, fixSecret(map[string]string{"accesskey": "access", "secretkey": "secret"}))
+		factory := &clientFactory{err: errors.New("test-error")}
+
+		provider := storeclass.New(reader, factory.newClient, newStore)
+
+		// When
+		_, _, err := provider.Get(ctx, storeClass.Name)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestExternalEndpoint(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		spec     v1beta1.StoreClassSpec
+		expected string
+	}{
+		"Explicit": {
+			spec:     v1beta1.StoreClassSpec{Endpoint: "minio:9000", ExternalEndpoint: "https://minio.example.com"},
+			expected: "https://minio.example.com",
+		},
+		"Insecure": {
+			spec:     v1beta1.StoreClassSpec{Endpoint: "minio:9000"},
+			expected: "http://minio:9000",
+		},
+		"Secure": {
+			spec:     v1beta1.StoreClassSpec{Endpoint: "minio:9000", UseSSL: true},
+			expected: "https://minio:9000",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// Given
+			g := gomega.NewGomegaWithT(t)
+
+			// When
+			result := storeclass.ExternalEndpoint(&testCase.spec)
+
+			// Then
+			g.Expect(result).To(gomega.Equal(testCase.expected))
+		})
+	}
+}
+
+type clientFactory struct {
+	calls     int
+	accessKey string
+	secretKey string
+	err       error
+}
+
+func (f *clientFactory) newClient(endpoint, accessKey, secretKey string, secure bool) (store.MinioClient, error) {
+	f.calls++
+	f.accessKey = accessKey
+	f.secretKey = secretKey
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return new(automock.MinioClient), nil
+}
+
+func newStore(client store.MinioClient) store.Store {
+	return store.New(client, 1, nil)
+}
+
+func fakeClient(g *gomega.GomegaWithT, objects ...runtime.Object) client.Client {
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(gomega.Succeed())
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+
+	return fake.NewFakeClientWithScheme(scheme, objects...)
+}
+
+func fixStoreClass(name string) *v1beta1.StoreClass {
+	return &v1beta1.StoreClass{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1beta1.StoreClassSpec{
+			Endpoint: "minio.test.local:9000",
+			CredentialsSecretRef: v1beta1.StoreClassCredentialsRef{
+				Name:      "test-secret",
+				Namespace: "test-namespace",
+			},
+		},
+	}
+}
+
+func fixSecret(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: "test-namespace",
+		},
+		Data: map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+
+	return secret
+}
@@ -0,0 +1,152 @@
+package storeclient
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go"
+	"github.com/pkg/errors"
+)
+
+// Factory creates a Minio client using the given credentials
+type Factory func(credentials Credentials) (*minio.Client, error)
+
+// NewMinioFactory returns a Factory for the given endpoint
+func NewMinioFactory(endpoint string, secure bool) Factory {
+	return func(credentials Credentials) (*minio.Client, error) {
+		return minio.New(endpoint, credentials.AccessKey, credentials.SecretKey, secure)
+	}
+}
+
+// Client is a Minio client rebuilt whenever the credentials change. Calls in progress keep using
+// the client they started with.
+type Client struct {
+	source  Source
+	factory Factory
+
+	mu          sync.RWMutex
+	client      *minio.Client
+	credentials Credentials
+}
+
+// New returns a Client built from the current credentials
+func New(source Source, factory Factory) (*Client, error) {
+	c := &Client{
+		source:  source,
+		factory: factory,
+	}
+
+	if _, err := c.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Refresh rebuilds the client if the credentials have changed and reports whether it was rebuilt
+func (c *Client) Refresh() (bool, error) {
+	credentials, err := c.source.Credentials()
+	if err != nil {
+		return false, errors.Wrap(err, "while reading store credentials")
+	}
+
+	c.mu.RLock()
+	unchanged := c.client != nil && c.credentials == credentials
+	c.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	client, err := c.factory(credentials)
+	if err != nil {
+		return false, errors.Wrap(err, "while creating store client")
+	}
+
+	c.mu.Lock()
+	c.client = client
+	c.credentials = credentials
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+// notifier is implemented by sources which report the changes of the credentials themselves
+type notifier interface {
+	Notify(stopCh <-chan struct{}, onChange func())
+}
+
+// Run keeps the client up to date until the stop channel is closed. Sources reporting their changes, such as
+// the credentials Secret, refresh the client on every change. Other sources are polled in the given interval.
+func (c *Client) Run(stopCh <-chan struct{}, interval time.Duration, onRefresh func(refreshed bool, err error)) {
+	if n, ok := c.source.(notifier); ok {
+		n.Notify(stopCh, func() {
+			onRefresh(c.Refresh())
+		})
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			onRefresh(c.Refresh())
+		}
+	}
+}
+
+func (c *Client) current() *minio.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.client
+}
+
+func (c *Client) FPutObjectWithContext(ctx context.Context, bucketName, objectName, filePath string, opts minio.PutObjectOptions) (int64, error) {
+	return c.current().FPutObjectWithContext(ctx, bucketName, objectName, filePath, opts)
+}
+
+func (c *Client) PutObjectWithContext(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (int64, error) {
+	return c.current().PutObjectWithContext(ctx, bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *Client) ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	return c.current().ListObjects(bucketName, objectPrefix, recursive, doneCh)
+}
+
+func (c *Client) ListBuckets() ([]minio.BucketInfo, error) {
+	return c.current().ListBuckets()
+}
+
+func (c *Client) MakeBucket(bucketName string, location string) error {
+	return c.current().MakeBucket(bucketName, location)
+}
+
+func (c *Client) BucketExists(bucketName string) (bool, error) {
+	return c.current().BucketExists(bucketName)
+}
+
+func (c *Client) RemoveBucket(bucketName string) error {
+	return c.current().RemoveBucket(bucketName)
+}
+
+func (c *Client) SetBucketPolicy(bucketName, policy string) error {
+	return c.current().SetBucketPolicy(bucketName, policy)
+}
+
+func (c *Client) GetBucketPolicy(bucketName string) (string, error) {
+	return c.current().GetBucketPolicy(bucketName)
+}
+
+func (c *Client) RemoveObjectsWithContext(ctx context.Context, bucketName string, objectsCh <-chan string) <-chan minio.RemoveObjectError {
+	return c.current().RemoveObjectsWithContext(ctx, bucketName, objectsCh)
+}
+
+func (c *Client) StatObject(bucketName, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	return c.current().StatObject(bucketName, objectName, opts)
+}
@@ -0,0 +1,204 @@
+package storeclient_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/rafter/internal/storeclient"
+	"github.com/kyma-project/rafter/internal/storeclient/automock"
+	"github.com/minio/minio-go"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestClient_Refresh(t *testing.T) {
+	t.Run("Unchanged", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		credentials := storeclient.Credentials{AccessKey: "access", SecretKey: "secret"}
+
+		source := new(automock.Source)
+		source.On("Credentials").Return(credentials, nil).Twice()
+		defer source.AssertExpectations(t)
+
+		factory := &minioFactory{}
+		client, err := storeclient.New(source, factory.new)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		refreshed, err := client.Refresh()
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(refreshed).To(gomega.BeFalse())
+		g.Expect(factory.credentials).To(gomega.Equal([]storeclient.Credentials{credentials}))
+	})
+
+	t.Run("Rotated", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		credentials := storeclient.Credentials{AccessKey: "access", SecretKey: "secret"}
+		rotated := storeclient.Credentials{AccessKey: "access", SecretKey: "rotated"}
+
+		source := new(automock.Source)
+		source.On("Credentials").Return(credentials, nil).Once()
+		source.On("Credentials").Return(rotated, nil).Once()
+		defer source.AssertExpectations(t)
+
+		factory := &minioFactory{}
+		client, err := storeclient.New(source, factory.new)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		refreshed, err := client.Refresh()
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(refreshed).To(gomega.BeTrue())
+		g.Expect(factory.credentials).To(gomega.Equal([]storeclient.Credentials{credentials, rotated}))
+	})
+
+	t.Run("SourceError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		credentials := storeclient.Credentials{AccessKey: "access", SecretKey: "secret"}
+
+		source := new(automock.Source)
+		source.On("Credentials").Return(credentials, nil).Once()
+		source.On("Credentials").Return(storeclient.Credentials{}, errors.New("test-error")).Once()
+		defer source.AssertExpectations(t)
+
+		factory := &minioFactory{}
+		client, err := storeclient.New(source, factory.new)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		refreshed, err := client.Refresh()
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(refreshed).To(gomega.BeFalse())
+		g.Expect(factory.credentials).To(gomega.HaveLen(1))
+	})
+
+	t.Run("InitialError", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		source := new(automock.Source)
+		source.On("Credentials").Return(storeclient.Credentials{}, errors.New("test-error")).Once()
+		defer source.AssertExpectations(t)
+
+		factory := &minioFactory{}
+
+		// When
+		_, err := storeclient.New(source, factory.new)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(factory.credentials).To(gomega.BeEmpty())
+	})
+}
+
+func TestClient_Run(t *testing.T) {
+	t.Run("Secret", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		secret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: "test-secret", Namespace: "test-namespace"},
+			Data: map[string][]byte{
+				"accesskey": []byte("access"),
+				"secretkey": []byte("secret"),
+			},
+		}
+		k8sClient := fake.NewSimpleClientset(secret)
+		cfg := storeclient.CredentialsConfig{
+			SecretName:      secret.Name,
+			SecretNamespace: secret.Namespace,
+			AccessKeyKey:    "accesskey",
+			SecretKeyKey:    "secretkey",
+		}
+
+		source, err := storeclient.NewSource(cfg, storeclient.Credentials{}, k8sClient.CoreV1())
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		factory := &minioFactory{}
+		client, err := storeclient.New(source, factory.new)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+
+		// When
+		go client.Run(stopCh, time.Hour, func(bool, error) {})
+
+		// Then
+		rotation := 0
+		g.Eventually(func() int {
+			rotation++
+			secret.Data["secretkey"] = []byte(fmt.Sprintf("rotated-%d", rotation))
+			_, err := k8sClient.CoreV1().Secrets(secret.Namespace).Update(secret)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			return factory.count()
+		}, 5*time.Second, 50*time.Millisecond).Should(gomega.BeNumerically(">", 1))
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		dir, err := ioutil.TempDir("", "credentials")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		cfg := storeclient.CredentialsConfig{
+			AccessKeyFile: filepath.Join(dir, "accesskey"),
+			SecretKeyFile: filepath.Join(dir, "secretkey"),
+		}
+		g.Expect(ioutil.WriteFile(cfg.AccessKeyFile, []byte("access"), 0600)).To(gomega.Succeed())
+		g.Expect(ioutil.WriteFile(cfg.SecretKeyFile, []byte("secret"), 0600)).To(gomega.Succeed())
+
+		source, err := storeclient.NewSource(cfg, storeclient.Credentials{}, nil)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		factory := &minioFactory{}
+		client, err := storeclient.New(source, factory.new)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+
+		// When
+		go client.Run(stopCh, 10*time.Millisecond, func(bool, error) {})
+		g.Expect(ioutil.WriteFile(cfg.SecretKeyFile, []byte("rotated"), 0600)).To(gomega.Succeed())
+
+		// Then
+		g.Eventually(factory.count, 5*time.Second).Should(gomega.Equal(2))
+	})
+}
+
+type minioFactory struct {
+	mu          sync.Mutex
+	credentials []storeclient.Credentials
+}
+
+func (f *minioFactory) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.credentials)
+}
+
+func (f *minioFactory) new(credentials storeclient.Credentials) (*minio.Client, error) {
+	f.mu.Lock()
+	f.credentials = append(f.credentials, credentials)
+	f.mu.Unlock()
+	return minio.New("minio.test.local:9000", credentials.AccessKey, credentials.SecretKey, false)
+}
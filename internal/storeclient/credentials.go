@@ -0,0 +1,157 @@
+package storeclient
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CredentialsConfig defines where the object store credentials are read from. The credentials Secret is watched,
+// the files are re-read in the refresh interval.
+type CredentialsConfig struct {
+	AccessKeyFile   string        `envconfig:"optional"`
+	SecretKeyFile   string        `envconfig:"optional"`
+	SecretName      string        `envconfig:"optional"`
+	SecretNamespace string        `envconfig:"optional"`
+	AccessKeyKey    string        `envconfig:"default=accesskey"`
+	SecretKeyKey    string        `envconfig:"default=secretkey"`
+	RefreshInterval time.Duration `envconfig:"default=1m"`
+}
+
+// Credentials holds the keys used to access the object store
+type Credentials struct {
+	AccessKey string
+	SecretKey string
+}
+
+//go:generate mockery -name=Source -output=automock -outpkg=automock -case=underscore
+type Source interface {
+	Credentials() (Credentials, error)
+}
+
+// NewSource returns a Source for the given configuration. The Secret takes precedence over the files,
+// which take precedence over the static credentials.
+func NewSource(cfg CredentialsConfig, static Credentials, secrets corev1.SecretsGetter) (Source, error) {
+	switch {
+	case cfg.SecretName != "":
+		if cfg.SecretNamespace == "" {
+			return nil, errors.Errorf("namespace of credentials Secret %s is required", cfg.SecretName)
+		}
+		return &secretSource{
+			secrets:      secrets.Secrets(cfg.SecretNamespace),
+			name:         cfg.SecretName,
+			accessKeyKey: cfg.AccessKeyKey,
+			secretKeyKey: cfg.SecretKeyKey,
+		}, nil
+	case cfg.AccessKeyFile != "" || cfg.SecretKeyFile != "":
+		if cfg.AccessKeyFile == "" || cfg.SecretKeyFile == "" {
+			return nil, errors.New("both access key and secret key files are required")
+		}
+		return &fileSource{
+			accessKeyFile: cfg.AccessKeyFile,
+			secretKeyFile: cfg.SecretKeyFile,
+		}, nil
+	case static.AccessKey != "" && static.SecretKey != "":
+		return &staticSource{credentials: static}, nil
+	default:
+		return nil, errors.New("store credentials are not configured")
+	}
+}
+
+type staticSource struct {
+	credentials Credentials
+}
+
+func (s *staticSource) Credentials() (Credentials, error) {
+	return s.credentials, nil
+}
+
+// Notify never reports a change, as the static credentials cannot change
+func (s *staticSource) Notify(stopCh <-chan struct{}, _ func()) {
+	<-stopCh
+}
+
+type fileSource struct {
+	accessKeyFile string
+	secretKeyFile string
+}
+
+func (s *fileSource) Credentials() (Credentials, error) {
+	accessKey, err := s.readFile(s.accessKeyFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	secretKey, err := s.readFile(s.secretKeyFile)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{AccessKey: accessKey, SecretKey: secretKey}, nil
+}
+
+func (*fileSource) readFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "while reading file %s", path)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+type secretSource struct {
+	secrets      corev1.SecretInterface
+	name         string
+	accessKeyKey string
+	secretKeyKey string
+}
+
+func (s *secretSource) Credentials() (Credentials, error) {
+	secret, err := s.secrets.Get(s.name, metav1.GetOptions{})
+	if err != nil {
+		return Credentials{}, errors.Wrapf(err, "while getting credentials Secret %s", s.name)
+	}
+
+	accessKey, ok := secret.Data[s.accessKeyKey]
+	if !ok {
+		return Credentials{}, errors.Errorf("key %s not found in Secret %s", s.accessKeyKey, s.name)
+	}
+
+	secretKey, ok := secret.Data[s.secretKeyKey]
+	if !ok {
+		return Credentials{}, errors.Errorf("key %s not found in Secret %s", s.secretKeyKey, s.name)
+	}
+
+	return Credentials{AccessKey: string(accessKey), SecretKey: string(secretKey)}, nil
+}
+
+// Notify calls onChange whenever the credentials Secret is created or updated until the stop channel is closed.
+// Only the configured Secret is listed and watched, so a Role restricted to its name is enough.
+func (s *secretSource) Notify(stopCh <-chan struct{}, onChange func()) {
+	selector := fields.OneTermEqualSelector("metadata.name", s.name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return s.secrets.List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return s.secrets.Watch(options)
+		},
+	}
+
+	_, informer := cache.NewInformer(lw, &v1.Secret{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { onChange() },
+		UpdateFunc: func(interface{}, interface{}) { onChange() },
+	})
+	informer.Run(stopCh)
+}
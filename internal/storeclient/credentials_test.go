@@ -0,0 +1,166 @@
+package storeclient_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/rafter/internal/storeclient"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewSource(t *testing.T) {
+	t.Run("Static", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		static := storeclient.Credentials{AccessKey: "access", SecretKey: "secret"}
+
+		source, err := storeclient.NewSource(storeclient.CredentialsConfig{}, static, nil)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		credentials, err := source.Credentials()
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(credentials).To(gomega.Equal(static))
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		dir, err := ioutil.TempDir("", "credentials")
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		accessKeyFile := filepath.Join(dir, "accesskey")
+		secretKeyFile := filepath.Join(dir, "secretkey")
+		g.Expect(ioutil.WriteFile(accessKeyFile, []byte("access\n"), 0600)).To(gomega.Succeed())
+		g.Expect(ioutil.WriteFile(secretKeyFile, []byte("secret"), 0600)).To(gomega.Succeed())
+
+		cfg := storeclient.CredentialsConfig{AccessKeyFile: accessKeyFile, SecretKeyFile: secretKeyFile}
+		source, err := storeclient.NewSource(cfg, storeclient.Credentials{}, nil)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		credentials, err := source.Credentials()
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(ioutil.WriteFile(secretKeyFile, []byte("rotated"), 0600)).To(gomega.Succeed())
+		rotated, rotatedErr := source.Credentials()
+
+		// Then
+		g.Expect(credentials).To(gomega.Equal(storeclient.Credentials{AccessKey: "access", SecretKey: "secret"}))
+		g.Expect(rotatedErr).NotTo(gomega.HaveOccurred())
+		g.Expect(rotated.SecretKey).To(gomega.Equal("rotated"))
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		cfg := storeclient.CredentialsConfig{AccessKeyFile: "/not/existing/accesskey", SecretKeyFile: "/not/existing/secretkey"}
+
+		source, err := storeclient.NewSource(cfg, storeclient.Credentials{}, nil)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		_, err = source.Credentials()
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("Secret", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		secret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: "test-secret", Namespace: "test-namespace"},
+			Data: map[string][]byte{
+				"accesskey": []byte("access"),
+				"secretkey": []byte("secret"),
+			},
+		}
+		client := fake.NewSimpleClientset(secret)
+		cfg := storeclient.CredentialsConfig{
+			SecretName:      secret.Name,
+			SecretNamespace: secret.Namespace,
+			AccessKeyKey:    "accesskey",
+			SecretKeyKey:    "secretkey",
+		}
+
+		source, err := storeclient.NewSource(cfg, storeclient.Credentials{AccessKey: "static", SecretKey: "static"}, client.CoreV1())
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		credentials, err := source.Credentials()
+
+		// Then
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(credentials).To(gomega.Equal(storeclient.Credentials{AccessKey: "access", SecretKey: "secret"}))
+	})
+
+	t.Run("SecretMissingKey", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		secret := &corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: "test-secret", Namespace: "test-namespace"},
+			Data: map[string][]byte{
+				"accesskey": []byte("access"),
+			},
+		}
+		client := fake.NewSimpleClientset(secret)
+		cfg := storeclient.CredentialsConfig{
+			SecretName:      secret.Name,
+			SecretNamespace: secret.Namespace,
+			AccessKeyKey:    "accesskey",
+			SecretKeyKey:    "secretkey",
+		}
+
+		source, err := storeclient.NewSource(cfg, storeclient.Credentials{}, client.CoreV1())
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// When
+		_, err = source.Credentials()
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("SecretWithoutNamespace", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		cfg := storeclient.CredentialsConfig{SecretName: "test-secret"}
+
+		// When
+		_, err := storeclient.NewSource(cfg, storeclient.Credentials{}, nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("SingleFile", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+		cfg := storeclient.CredentialsConfig{AccessKeyFile: "/etc/credentials/accesskey"}
+
+		// When
+		_, err := storeclient.NewSource(cfg, storeclient.Credentials{}, nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		// Given
+		g := gomega.NewGomegaWithT(t)
+
+		// When
+		_, err := storeclient.NewSource(storeclient.CredentialsConfig{}, storeclient.Credentials{}, nil)
+
+		// Then
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
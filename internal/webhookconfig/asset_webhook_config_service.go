@@ -20,8 +20,26 @@ type Config struct {
 type AssetWebhookConfigMap = map[v1beta1.AssetGroupSourceType]AssetWebhookConfig
 
 type WebhookService struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// +optional
+	Scheme v1beta1.WebhookScheme `json:"scheme,omitempty"`
+	// +optional
+	URL string `json:"url,omitempty"`
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// +optional
+	Authentication *v1beta1.WebhookAuthentication `json:"authentication,omitempty"`
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// +optional
+	FailurePolicy v1beta1.WebhookFailurePolicy `json:"failurePolicy,omitempty"`
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
 
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
@@ -32,12 +50,32 @@ type WebhookService struct {
 type AssetWebhookService struct {
 	WebhookService `json:",inline"`
 	Parameters     *runtime.RawExtension `json:"parameters,omitempty"`
+	// +optional
+	Batch bool `json:"batch,omitempty"`
+	// +optional
+	MaxBatchSize *int32 `json:"maxBatchSize,omitempty"`
 }
 
 type AssetWebhookConfig struct {
 	Validations        []AssetWebhookService `json:"validations,omitempty"`
 	Mutations          []AssetWebhookService `json:"mutations,omitempty"`
 	MetadataExtractors []WebhookService      `json:"metadataExtractors,omitempty"`
+	Notifications      []WebhookService      `json:"notifications,omitempty"`
+}
+
+// Services returns all webhooks of the configuration
+func (c AssetWebhookConfig) Services() []WebhookService {
+	var result []WebhookService
+	for _, service := range c.Validations {
+		result = append(result, service.WebhookService)
+	}
+	for _, service := range c.Mutations {
+		result = append(result, service.WebhookService)
+	}
+	result = append(result, c.MetadataExtractors...)
+	result = append(result, c.Notifications...)
+
+	return result
 }
 
 type assetWebhookConfigService struct {
@@ -0,0 +1,8 @@
+package v1beta1
+
+// ResyncRequestedAtAnnotation triggers processing of the resource from scratch whenever its value changes
+const ResyncRequestedAtAnnotation = "rafter.kyma-project.io/resync-requested-at"
+
+// AllowedNamespacesAnnotation lists comma-separated namespaces whose Assets may reference the ClusterBucket.
+// An asterisk allows all namespaces.
+const AllowedNamespacesAnnotation = "rafter.kyma-project.io/allowed-namespaces"
@@ -13,6 +13,31 @@ type CommonAssetSpec struct {
 	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
 	// +optional
 	DisplayName string `json:"displayName,omitempty"`
+	// +optional
+	RetryPolicy *AssetRetryPolicy `json:"retryPolicy,omitempty"`
+	// Suspend pauses all remote operations on the asset apart from deletion
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// DryRun processes the asset content with webhooks without publishing it in the bucket
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// TTL is the period after creation when the asset is deleted
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// ExpiresAt is the time when the asset is deleted. The earlier time wins when TTL is also set.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// AssetRetryPolicy overrides the default backoff of failed assets
+type AssetRetryPolicy struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// +optional
+	InitialInterval *metav1.Duration `json:"initialInterval,omitempty"`
+	// +optional
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
 }
 
 // CommonAssetStatus defines the observed state of Asset
@@ -23,6 +48,84 @@ type CommonAssetStatus struct {
 	AssetRef           AssetStatusRef `json:"assetRef,omitempty"`
 	LastHeartbeatTime  metav1.Time    `json:"lastHeartbeatTime"`
 	ObservedGeneration int64          `json:"observedGeneration"`
+	// +optional
+	Conditions []AssetCondition `json:"conditions,omitempty"`
+	// +optional
+	LastResyncRequestedAt string `json:"lastResyncRequestedAt,omitempty"`
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// +optional
+	WebhooksFingerprint string `json:"webhooksFingerprint,omitempty"`
+	// ConfigMapResourceVersion is the version of the source ConfigMap the content was loaded from
+	// +optional
+	ConfigMapResourceVersion string `json:"configMapResourceVersion,omitempty"`
+	// DryRunFiles are the files processed during a dry run. The published content stays in AssetRef.
+	// +optional
+	DryRunFiles []AssetFile `json:"dryRunFiles,omitempty"`
+	// WebhookMessages are the messages returned by mutation and validation webhooks during a dry run
+	// +optional
+	WebhookMessages []AssetWebhookMessage `json:"webhookMessages,omitempty"`
+	// WebhookWarnings are the failures of webhooks ignored due to their failure policy
+	// +optional
+	WebhookWarnings []string `json:"webhookWarnings,omitempty"`
+	// ExpirationTime is the time when the asset is deleted
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// PendingNotification is the notification which notification webhooks haven't accepted yet
+	// +optional
+	PendingNotification *AssetPendingNotification `json:"pendingNotification,omitempty"`
+}
+
+// AssetPendingNotification is a failed notification retried with the backoff of failed assets
+type AssetPendingNotification struct {
+	Event   AssetNotificationEvent `json:"event"`
+	BaseURL string                 `json:"baseUrl,omitempty"`
+	// Attempts is the number of failed attempts to send the notification
+	Attempts        int         `json:"attempts"`
+	NextAttemptTime metav1.Time `json:"nextAttemptTime"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Uploaded;Deleted
+type AssetNotificationEvent string
+
+const (
+	AssetNotificationUploaded AssetNotificationEvent = "Uploaded"
+	AssetNotificationDeleted  AssetNotificationEvent = "Deleted"
+)
+
+// AssetWebhookMessage is a message returned by a webhook for a single file
+type AssetWebhookMessage struct {
+	Webhook  string `json:"webhook"`
+	Filename string `json:"filename"`
+	Message  string `json:"message"`
+}
+
+type AssetConditionType string
+
+const (
+	AssetConditionSourcePulled      AssetConditionType = "SourcePulled"
+	AssetConditionMutated           AssetConditionType = "Mutated"
+	AssetConditionValidated         AssetConditionType = "Validated"
+	AssetConditionMetadataExtracted AssetConditionType = "MetadataExtracted"
+	AssetConditionUploaded          AssetConditionType = "Uploaded"
+	AssetConditionReady             AssetConditionType = "Ready"
+)
+
+// AssetCondition describes the state of a single stage of asset processing
+type AssetCondition struct {
+	Type AssetConditionType `json:"type"`
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status             metav1.ConditionStatus `json:"status"`
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime"`
+	// +optional
+	Reason AssetReason `json:"reason,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type AssetPhase string
@@ -31,21 +134,60 @@ const (
 	AssetReady   AssetPhase = "Ready"
 	AssetPending AssetPhase = "Pending"
 	AssetFailed  AssetPhase = "Failed"
+	// AssetDryRun means the content has been processed in dry-run mode and nothing has been published
+	AssetDryRun AssetPhase = "DryRun"
 )
 
 type AssetStatusRef struct {
-	BaseURL string      `json:"baseUrl"`
-	Files   []AssetFile `json:"files,omitempty"`
+	BaseURL   string      `json:"baseUrl"`
+	Files     []AssetFile `json:"files,omitempty"`
+	TotalSize int64       `json:"totalSize,omitempty"`
+	FileCount int         `json:"fileCount,omitempty"`
 }
 
 type AssetFile struct {
-	Name     string                `json:"name"`
-	Metadata *runtime.RawExtension `json:"metadata,omitempty"`
+	Name        string                `json:"name"`
+	Metadata    *runtime.RawExtension `json:"metadata,omitempty"`
+	Md5         string                `json:"md5,omitempty"`
+	Sha256      string                `json:"sha256,omitempty"`
+	Size        int64                 `json:"size,omitempty"`
+	ContentType string                `json:"contentType,omitempty"`
+	URL         string                `json:"url,omitempty"`
 }
 
+// WebhookService references a webhook either by an in-cluster Service or by a URL
 type WebhookService struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
+	// Name of the Service. It is required unless URL is set.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace of the Service. It is required unless URL is set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Port of the Service. Defaults to the port of the scheme.
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+	// Scheme used to call the Service. Defaults to http.
+	// +optional
+	Scheme WebhookScheme `json:"scheme,omitempty"`
+	// URL of the webhook in the scheme://host:port/path format. It takes precedence over the Service reference.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// CABundle is a PEM encoded CA bundle used to verify the webhook certificate. System roots are used if it is empty.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// Authentication overrides the credentials of the manager sent to the webhook
+	// +optional
+	Authentication *WebhookAuthentication `json:"authentication,omitempty"`
+	// Timeout of a single call to the webhook. Defaults to the timeout configured in the manager.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy defines how failed calls to the webhook are handled. Defaults to Fail.
+	// +optional
+	FailurePolicy WebhookFailurePolicy `json:"failurePolicy,omitempty"`
+	// MaxRetries is the number of retries of calls failed due to connection errors or 5xx responses. Defaults to the number configured in the manager.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
 
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
@@ -53,9 +195,46 @@ type WebhookService struct {
 	Filter string `json:"filter,omitempty"`
 }
 
+// WebhookAuthentication selects the credentials of the manager sent to the webhook
+type WebhookAuthentication struct {
+	// ClientCertificate sends the client certificate of the manager. Defaults to true if the manager has a client certificate.
+	// +optional
+	ClientCertificate *bool `json:"clientCertificate,omitempty"`
+	// ServiceAccountToken sends the ServiceAccount token of the manager as a bearer token. The token is sent only over HTTPS to
+	// Services of webhooks configured in the webhook ConfigMap and never to URLs. Defaults to true for such Services if the manager has a token.
+	// +optional
+	ServiceAccountToken *bool `json:"serviceAccountToken,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=http;https;""
+type WebhookScheme string
+
+const (
+	WebhookSchemeHTTP  WebhookScheme = "http"
+	WebhookSchemeHTTPS WebhookScheme = "https"
+)
+
+// WebhookFailurePolicy defines how failed calls to the webhook are handled
+// +kubebuilder:validation:Enum=Fail;Ignore;""
+type WebhookFailurePolicy string
+
+const (
+	// WebhookFailurePolicyFail fails the Asset if the webhook cannot be called or returns an unexpected response
+	WebhookFailurePolicyFail WebhookFailurePolicy = "Fail"
+	// WebhookFailurePolicyIgnore skips the webhook if it cannot be called or returns an unexpected response
+	WebhookFailurePolicyIgnore WebhookFailurePolicy = "Ignore"
+)
+
 type AssetWebhookService struct {
 	WebhookService `json:",inline"`
 	Parameters     *runtime.RawExtension `json:"parameters,omitempty"`
+	// Batch sends multiple files in a single request using the batch protocol instead of a request per file
+	// +optional
+	Batch bool `json:"batch,omitempty"`
+	// MaxBatchSize is the maximum number of files sent in a single batch request. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBatchSize *int32 `json:"maxBatchSize,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=single;package;index;configmap
@@ -70,8 +249,20 @@ const (
 
 type AssetBucketRef struct {
 	Name string `json:"name"`
+	// Kind of the bucket referenced by an Asset, either Bucket or ClusterBucket. Defaults to Bucket.
+	// ClusterAssets always reference ClusterBuckets.
+	// +optional
+	Kind AssetBucketRefKind `json:"kind,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=Bucket;ClusterBucket;""
+type AssetBucketRefKind string
+
+const (
+	AssetBucketRefKindBucket        AssetBucketRefKind = "Bucket"
+	AssetBucketRefKindClusterBucket AssetBucketRefKind = "ClusterBucket"
+)
+
 type AssetSource struct {
 	Mode AssetMode `json:"mode"`
 	URL  string    `json:"url"`
@@ -86,6 +277,10 @@ type AssetSource struct {
 
 	// +optional
 	MetadataWebhookService []WebhookService `json:"metadataWebhookService,omitempty"`
+
+	// NotificationWebhookService is called after the asset content is uploaded or deleted
+	// +optional
+	NotificationWebhookService []WebhookService `json:"notificationWebhookService,omitempty"`
 }
 
 type AssetReason string
@@ -110,6 +305,18 @@ const (
 	AssetCleanupError                   AssetReason = "CleanupError"
 	AssetCleaned                        AssetReason = "Cleaned"
 	AssetScheduled                      AssetReason = "Scheduled"
+	AssetContentModified                AssetReason = "ContentModified"
+	AssetUnexpectedContent              AssetReason = "UnexpectedContent"
+	AssetSuspended                      AssetReason = "Suspended"
+	AssetExpirationScheduled            AssetReason = "ExpirationScheduled"
+	AssetExpired                        AssetReason = "Expired"
+	AssetDryRunSucceeded                AssetReason = "DryRunSucceeded"
+	AssetNotificationFailed             AssetReason = "NotificationFailed"
+	AssetNotificationAbandoned          AssetReason = "NotificationAbandoned"
+	AssetRetriesExhausted               AssetReason = "RetriesExhausted"
+	AssetWebhookFailureIgnored          AssetReason = "WebhookFailureIgnored"
+	AssetRemoteDeletionSkipped          AssetReason = "RemoteDeletionSkipped"
+	AssetBucketNotAllowed               AssetReason = "BucketNotAllowed"
 )
 
 func (r AssetReason) String() string {
@@ -156,6 +363,30 @@ func (r AssetReason) Message() string {
 		return "Old asset content hes been removed"
 	case AssetScheduled:
 		return "Asset scheduled for processing"
+	case AssetContentModified:
+		return "Asset content has been modified in remote storage: %s"
+	case AssetUnexpectedContent:
+		return "Unexpected objects found in remote storage, they are left untouched: %s"
+	case AssetSuspended:
+		return "Asset reconciliation is suspended"
+	case AssetExpirationScheduled:
+		return "Asset will be deleted at %s"
+	case AssetExpired:
+		return "Asset has expired at %s and is being deleted"
+	case AssetDryRunSucceeded:
+		return "Asset content has been processed in dry-run mode without publishing"
+	case AssetNotificationFailed:
+		return "Sending notification failed due to error %s"
+	case AssetNotificationAbandoned:
+		return "%s notification has been dropped after %d failed attempts"
+	case AssetRetriesExhausted:
+		return "Retrying has stopped after %d failed attempts"
+	case AssetWebhookFailureIgnored:
+		return "Ignored failure of webhook %s"
+	case AssetRemoteDeletionSkipped:
+		return "Remote asset content has been left in the store due to error %s"
+	case AssetBucketNotAllowed:
+		return "Namespace is not allowed to use ClusterBucket %s"
 	default:
 		return ""
 	}
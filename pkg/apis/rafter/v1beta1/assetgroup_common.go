@@ -11,6 +11,18 @@ type CommonAssetGroupSpec struct {
 	BucketRef   AssetGroupBucketRef `json:"bucketRef,omitempty"`
 	// +kubebuilder:validation:MinItems=1
 	Sources []Source `json:"sources"`
+	// Suspend pauses management of the assets
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+	// DryRun processes the content of all assets with webhooks without publishing it
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+	// TTL is the period after creation when the asset group is deleted together with its assets
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// ExpiresAt is the time when the asset group is deleted together with its assets. The earlier time wins when TTL is also set.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 type AssetGroupBucketRef struct {
@@ -44,13 +56,14 @@ type Source struct {
 	DisplayName string `json:"displayName,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=Pending;Ready;Failed
+// +kubebuilder:validation:Enum=Pending;Ready;Failed;DryRun
 type AssetGroupPhase string
 
 const (
 	AssetGroupPending AssetGroupPhase = "Pending"
 	AssetGroupReady   AssetGroupPhase = "Ready"
 	AssetGroupFailed  AssetGroupPhase = "Failed"
+	AssetGroupDryRun  AssetGroupPhase = "DryRun"
 )
 
 type CommonAssetGroupStatus struct {
@@ -58,6 +71,11 @@ type CommonAssetGroupStatus struct {
 	Reason            AssetGroupReason `json:"reason,omitempty"`
 	Message           string           `json:"message,omitempty"`
 	LastHeartbeatTime metav1.Time      `json:"lastHeartbeatTime"`
+	// +optional
+	LastResyncRequestedAt string `json:"lastResyncRequestedAt,omitempty"`
+	// ExpirationTime is the time when the asset group is deleted
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
 }
 
 type AssetGroupReason string
@@ -75,9 +93,15 @@ const (
 	AssetGroupAssetsUpdateFailed         AssetGroupReason = "AssetsUpdateFailed"
 	AssetGroupAssetsReady                AssetGroupReason = "AssetsReady"
 	AssetGroupWaitingForAssets           AssetGroupReason = "WaitingForAssets"
+	AssetGroupAssetsDryRunCompleted      AssetGroupReason = "AssetsDryRunCompleted"
 	AssetGroupBucketError                AssetGroupReason = "BucketError"
 	AssetGroupAssetsWebhookGetFailed     AssetGroupReason = "AssetsWebhookGetFailed"
 	AssetGroupAssetsSpecValidationFailed AssetGroupReason = "AssetsSpecValidationFailed"
+	AssetGroupAssetsResyncRequested      AssetGroupReason = "AssetsResyncRequested"
+	AssetGroupAssetsResyncFailed         AssetGroupReason = "AssetsResyncFailed"
+	AssetGroupSuspended                  AssetGroupReason = "Suspended"
+	AssetGroupExpirationScheduled        AssetGroupReason = "ExpirationScheduled"
+	AssetGroupExpired                    AssetGroupReason = "Expired"
 )
 
 func (r AssetGroupReason) String() string {
@@ -110,12 +134,24 @@ func (r AssetGroupReason) Message() string {
 		return "Assets are ready to use"
 	case AssetGroupWaitingForAssets:
 		return "Waiting for assets to be in Ready phase"
+	case AssetGroupAssetsDryRunCompleted:
+		return "Dry run of assets has completed, nothing has been published"
 	case AssetGroupBucketError:
 		return "Couldn't ensure if bucket exist due to error %s"
 	case AssetGroupAssetsWebhookGetFailed:
 		return "Unable to get webhook configuration %s"
 	case AssetGroupAssetsSpecValidationFailed:
 		return "Invalid asset specification, %s"
+	case AssetGroupAssetsResyncRequested:
+		return "Assets resync has been requested"
+	case AssetGroupAssetsResyncFailed:
+		return "Assets couldn't be resynced due to error %s"
+	case AssetGroupSuspended:
+		return "Asset group reconciliation is suspended"
+	case AssetGroupExpirationScheduled:
+		return "Asset group will be deleted at %s"
+	case AssetGroupExpired:
+		return "Asset group has expired at %s and is being deleted"
 	default:
 		return ""
 	}
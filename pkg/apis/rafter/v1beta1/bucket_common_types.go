@@ -11,6 +11,31 @@ type CommonBucketSpec struct {
 
 	// +optional
 	Policy BucketPolicy `json:"policy,omitempty"`
+
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// RemoteName is the exact name of the remote bucket. It is created when it doesn't exist. An existing bucket is
+	// only adopted when the controller manager allows adopting its name, and then defaults to the Retain deletion policy.
+	// A name is generated when it is empty.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=^[a-z0-9][a-z0-9.-]*[a-z0-9]$
+	// +optional
+	RemoteName string `json:"remoteName,omitempty"`
+
+	// DeletionPolicy defines what happens with the remote bucket when the resource is deleted.
+	// Defaults to Retain for adopted buckets and to Delete otherwise. Retained remote buckets are marked,
+	// so the garbage collector never removes them.
+	// +optional
+	DeletionPolicy BucketDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// StoreClassName is the name of the StoreClass the bucket is created in. The default store is used when it is empty.
+	// +optional
+	StoreClassName string `json:"storeClassName,omitempty"`
+
+	// Suspend pauses all remote operations on the bucket apart from deletion
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=us-east-1;us-west-1;us-west-2;eu-west-1;eu-central-1;ap-southeast-1;ap-southeast-2;ap-northeast-1;sa-east-1;""
@@ -38,15 +63,68 @@ const (
 	BucketPolicyReadWrite BucketPolicy = "readwrite"
 )
 
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan;""
+type BucketDeletionPolicy string
+
+const (
+	// BucketDeletionPolicyDelete removes the remote bucket along with its content when the bucket is deleted,
+	// and the content of an asset when the asset is deleted
+	BucketDeletionPolicyDelete BucketDeletionPolicy = "Delete"
+
+	// BucketDeletionPolicyRetain keeps the remote bucket with all its content. Neither deleting the bucket nor
+	// deleting its assets removes any object, so the remote bucket can be adopted again.
+	BucketDeletionPolicyRetain BucketDeletionPolicy = "Retain"
+
+	// BucketDeletionPolicyOrphan keeps only the empty remote bucket. Deleting the bucket removes all its content,
+	// and deleting an asset removes the content of the asset, so no content outlives the resources in any order.
+	BucketDeletionPolicyOrphan BucketDeletionPolicy = "Orphan"
+)
+
+// +kubebuilder:validation:Enum=SSE-S3;SSE-C
+type BucketEncryptionMode string
+
+const (
+	BucketEncryptionSSES3 BucketEncryptionMode = "SSE-S3"
+	BucketEncryptionSSEC  BucketEncryptionMode = "SSE-C"
+)
+
+type BucketEncryption struct {
+	Mode BucketEncryptionMode `json:"mode"`
+
+	// KeySecretRef points to the customer-provided key used in the SSE-C mode. Objects encrypted this way cannot be
+	// read without the key, so the SSE-C mode requires the none bucket policy.
+	// +optional
+	KeySecretRef *SecretKeyRef `json:"keySecretRef,omitempty"`
+}
+
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+
+	// Namespace defaults to the namespace of the Bucket and is required for ClusterBuckets.
+	// A Bucket can only reference a Secret from its own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // CommonBucketStatus defines the observed state of Bucket
 type CommonBucketStatus struct {
-	URL                string       `json:"url,omitempty"`
-	Phase              BucketPhase  `json:"phase,omitempty"`
-	Message            string       `json:"message,omitempty"`
-	Reason             BucketReason `json:"reason,omitempty"`
-	RemoteName         string       `json:"remoteName,omitempty"`
-	LastHeartbeatTime  metav1.Time  `json:"lastHeartbeatTime,omitempty"`
-	ObservedGeneration int64        `json:"observedGeneration"`
+	URL        string       `json:"url,omitempty"`
+	Phase      BucketPhase  `json:"phase,omitempty"`
+	Message    string       `json:"message,omitempty"`
+	Reason     BucketReason `json:"reason,omitempty"`
+	RemoteName string       `json:"remoteName,omitempty"`
+	// +optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+	// +optional
+	DeletionPolicy BucketDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// +optional
+	StoreClassName string `json:"storeClassName,omitempty"`
+	// Adopted is true when the remote bucket existed before the resource
+	// +optional
+	Adopted            bool        `json:"adopted,omitempty"`
+	LastHeartbeatTime  metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	ObservedGeneration int64       `json:"observedGeneration"`
 }
 
 type BucketPhase string
@@ -70,6 +148,15 @@ const (
 	BucketPolicyUpdateFailed       BucketReason = "BucketPolicyUpdateFailed"
 	BucketPolicyVerificationFailed BucketReason = "BucketPolicyVerificationFailed"
 	BucketPolicyHasBeenChanged     BucketReason = "BucketPolicyHasBeenChanged"
+	BucketEncryptionInvalid        BucketReason = "BucketEncryptionInvalid"
+	BucketAdopted                  BucketReason = "BucketAdopted"
+	BucketAdoptionNotAllowed       BucketReason = "BucketAdoptionNotAllowed"
+	BucketRemoteNameInvalid        BucketReason = "BucketRemoteNameInvalid"
+	BucketRemoteNameConflict       BucketReason = "BucketRemoteNameConflict"
+	BucketRetained                 BucketReason = "BucketRetained"
+	BucketStoreClassInvalid        BucketReason = "BucketStoreClassInvalid"
+	BucketRemoteDeletionSkipped    BucketReason = "RemoteDeletionSkipped"
+	BucketSuspended                BucketReason = "Suspended"
 )
 
 func (r BucketReason) String() string {
@@ -94,6 +181,24 @@ func (r BucketReason) Message() string {
 		return "Bucket policy couldn't be verified due to error %s"
 	case BucketPolicyHasBeenChanged:
 		return "Remote bucket policy has been changed"
+	case BucketEncryptionInvalid:
+		return "Bucket encryption is invalid due to error %s"
+	case BucketAdopted:
+		return "Existing bucket %s has been adopted"
+	case BucketAdoptionNotAllowed:
+		return "Adopting existing bucket %s is not allowed"
+	case BucketRemoteNameInvalid:
+		return "Remote bucket name is invalid due to error %s"
+	case BucketRemoteNameConflict:
+		return "Remote bucket %s is already used by %s"
+	case BucketRetained:
+		return "Remote bucket %s has been retained due to deletion policy %s"
+	case BucketStoreClassInvalid:
+		return "Bucket store class is invalid due to error %s"
+	case BucketRemoteDeletionSkipped:
+		return "Remote bucket %s has been left in the store due to error %s"
+	case BucketSuspended:
+		return "Bucket reconciliation is suspended"
 	default:
 		return ""
 	}
@@ -0,0 +1,61 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StoreClassSpec defines the object store used by buckets that select the StoreClass
+type StoreClassSpec struct {
+	Endpoint string `json:"endpoint"`
+
+	// +optional
+	ExternalEndpoint string `json:"externalEndpoint,omitempty"`
+
+	// +optional
+	UseSSL bool `json:"useSSL,omitempty"`
+
+	// +optional
+	Region BucketRegion `json:"region,omitempty"`
+
+	CredentialsSecretRef StoreClassCredentialsRef `json:"credentialsSecretRef"`
+}
+
+// StoreClassCredentialsRef points to the Secret with the access and secret keys of the object store
+type StoreClassCredentialsRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// AccessKeyKey is the key of the access key in the Secret. Defaults to accesskey.
+	// +optional
+	AccessKeyKey string `json:"accessKeyKey,omitempty"`
+
+	// SecretKeyKey is the key of the secret key in the Secret. Defaults to secretkey.
+	// +optional
+	SecretKeyKey string `json:"secretKeyKey,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// StoreClass is the Schema for the storeclasses API
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.endpoint"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type StoreClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StoreClassSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StoreClassList contains a list of StoreClass
+type StoreClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StoreClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StoreClass{}, &StoreClassList{})
+}
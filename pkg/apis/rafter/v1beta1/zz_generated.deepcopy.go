@@ -5,6 +5,7 @@
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -50,6 +51,22 @@ func (in *AssetBucketRef) DeepCopy() *AssetBucketRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetCondition) DeepCopyInto(out *AssetCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetCondition.
+func (in *AssetCondition) DeepCopy() *AssetCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetFile) DeepCopyInto(out *AssetFile) {
 	*out = *in
@@ -76,7 +93,7 @@ func (in *AssetGroup) DeepCopyInto(out *AssetGroup) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetGroup.
@@ -163,7 +180,7 @@ func (in *AssetGroupSpec) DeepCopy() *AssetGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetGroupStatus) DeepCopyInto(out *AssetGroupStatus) {
 	*out = *in
-	out.CommonAssetGroupStatus = in.CommonAssetGroupStatus
+	in.CommonAssetGroupStatus.DeepCopyInto(&out.CommonAssetGroupStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetGroupStatus.
@@ -208,6 +225,47 @@ func (in *AssetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetPendingNotification) DeepCopyInto(out *AssetPendingNotification) {
+	*out = *in
+	in.NextAttemptTime.DeepCopyInto(&out.NextAttemptTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetPendingNotification.
+func (in *AssetPendingNotification) DeepCopy() *AssetPendingNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetPendingNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetRetryPolicy) DeepCopyInto(out *AssetRetryPolicy) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetRetryPolicy.
+func (in *AssetRetryPolicy) DeepCopy() *AssetRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetSource) DeepCopyInto(out *AssetSource) {
 	*out = *in
@@ -228,7 +286,16 @@ func (in *AssetSource) DeepCopyInto(out *AssetSource) {
 	if in.MetadataWebhookService != nil {
 		in, out := &in.MetadataWebhookService, &out.MetadataWebhookService
 		*out = make([]WebhookService, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NotificationWebhookService != nil {
+		in, out := &in.NotificationWebhookService, &out.NotificationWebhookService
+		*out = make([]WebhookService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -296,15 +363,35 @@ func (in *AssetStatusRef) DeepCopy() *AssetStatusRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetWebhookMessage) DeepCopyInto(out *AssetWebhookMessage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetWebhookMessage.
+func (in *AssetWebhookMessage) DeepCopy() *AssetWebhookMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetWebhookMessage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetWebhookService) DeepCopyInto(out *AssetWebhookService) {
 	*out = *in
-	out.WebhookService = in.WebhookService
+	in.WebhookService.DeepCopyInto(&out.WebhookService)
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxBatchSize != nil {
+		in, out := &in.MaxBatchSize, &out.MaxBatchSize
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetWebhookService.
@@ -322,8 +409,8 @@ func (in *Bucket) DeepCopyInto(out *Bucket) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
@@ -344,6 +431,26 @@ func (in *Bucket) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryption) DeepCopyInto(out *BucketEncryption) {
+	*out = *in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryption.
+func (in *BucketEncryption) DeepCopy() *BucketEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketList) DeepCopyInto(out *BucketList) {
 	*out = *in
@@ -379,7 +486,7 @@ func (in *BucketList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 	*out = *in
-	out.CommonBucketSpec = in.CommonBucketSpec
+	in.CommonBucketSpec.DeepCopyInto(&out.CommonBucketSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketSpec.
@@ -395,7 +502,7 @@ func (in *BucketSpec) DeepCopy() *BucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 	*out = *in
-	out.CommonBucketStatus = in.CommonBucketStatus
+	in.CommonBucketStatus.DeepCopyInto(&out.CommonBucketStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
@@ -441,7 +548,7 @@ func (in *ClusterAssetGroup) DeepCopyInto(out *ClusterAssetGroup) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAssetGroup.
@@ -513,7 +620,7 @@ func (in *ClusterAssetGroupSpec) DeepCopy() *ClusterAssetGroupSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAssetGroupStatus) DeepCopyInto(out *ClusterAssetGroupStatus) {
 	*out = *in
-	out.CommonAssetGroupStatus = in.CommonAssetGroupStatus
+	in.CommonAssetGroupStatus.DeepCopyInto(&out.CommonAssetGroupStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAssetGroupStatus.
@@ -595,8 +702,8 @@ func (in *ClusterBucket) DeepCopyInto(out *ClusterBucket) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBucket.
@@ -652,7 +759,7 @@ func (in *ClusterBucketList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBucketSpec) DeepCopyInto(out *ClusterBucketSpec) {
 	*out = *in
-	out.CommonBucketSpec = in.CommonBucketSpec
+	in.CommonBucketSpec.DeepCopyInto(&out.CommonBucketSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBucketSpec.
@@ -668,7 +775,7 @@ func (in *ClusterBucketSpec) DeepCopy() *ClusterBucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBucketStatus) DeepCopyInto(out *ClusterBucketStatus) {
 	*out = *in
-	out.CommonBucketStatus = in.CommonBucketStatus
+	in.CommonBucketStatus.DeepCopyInto(&out.CommonBucketStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBucketStatus.
@@ -692,6 +799,15 @@ func (in *CommonAssetGroupSpec) DeepCopyInto(out *CommonAssetGroupSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonAssetGroupSpec.
@@ -708,6 +824,10 @@ func (in *CommonAssetGroupSpec) DeepCopy() *CommonAssetGroupSpec {
 func (in *CommonAssetGroupStatus) DeepCopyInto(out *CommonAssetGroupStatus) {
 	*out = *in
 	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonAssetGroupStatus.
@@ -730,6 +850,20 @@ func (in *CommonAssetSpec) DeepCopyInto(out *CommonAssetSpec) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(AssetRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonAssetSpec.
@@ -747,6 +881,41 @@ func (in *CommonAssetStatus) DeepCopyInto(out *CommonAssetStatus) {
 	*out = *in
 	in.AssetRef.DeepCopyInto(&out.AssetRef)
 	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]AssetCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DryRunFiles != nil {
+		in, out := &in.DryRunFiles, &out.DryRunFiles
+		*out = make([]AssetFile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WebhookMessages != nil {
+		in, out := &in.WebhookMessages, &out.WebhookMessages
+		*out = make([]AssetWebhookMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookWarnings != nil {
+		in, out := &in.WebhookWarnings, &out.WebhookWarnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingNotification != nil {
+		in, out := &in.PendingNotification, &out.PendingNotification
+		*out = new(AssetPendingNotification)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonAssetStatus.
@@ -762,6 +931,11 @@ func (in *CommonAssetStatus) DeepCopy() *CommonAssetStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonBucketSpec) DeepCopyInto(out *CommonBucketSpec) {
 	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonBucketSpec.
@@ -777,6 +951,11 @@ func (in *CommonBucketSpec) DeepCopy() *CommonBucketSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonBucketStatus) DeepCopyInto(out *CommonBucketStatus) {
 	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
 }
 
@@ -790,6 +969,21 @@ func (in *CommonBucketStatus) DeepCopy() *CommonBucketStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -810,9 +1004,148 @@ func (in *Source) DeepCopy() *Source {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreClass) DeepCopyInto(out *StoreClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreClass.
+func (in *StoreClass) DeepCopy() *StoreClass {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StoreClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreClassCredentialsRef) DeepCopyInto(out *StoreClassCredentialsRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreClassCredentialsRef.
+func (in *StoreClassCredentialsRef) DeepCopy() *StoreClassCredentialsRef {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreClassCredentialsRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreClassList) DeepCopyInto(out *StoreClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StoreClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreClassList.
+func (in *StoreClassList) DeepCopy() *StoreClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StoreClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreClassSpec) DeepCopyInto(out *StoreClassSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreClassSpec.
+func (in *StoreClassSpec) DeepCopy() *StoreClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuthentication) DeepCopyInto(out *WebhookAuthentication) {
+	*out = *in
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuthentication.
+func (in *WebhookAuthentication) DeepCopy() *WebhookAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookService) DeepCopyInto(out *WebhookService) {
 	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(WebhookAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookService.
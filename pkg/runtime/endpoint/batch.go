@@ -0,0 +1,80 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// BatchField is the form field marking a batch request. Files of a batch request are sent in parts named by their paths.
+const BatchField = "batch"
+
+// BatchResult is the result of processing a single file of a batch request.
+type BatchResult struct {
+	FilePath string `json:"filePath"`
+	Success  bool   `json:"success"`
+	Modified bool   `json:"modified,omitempty"`
+	Content  []byte `json:"content,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// BatchResponse is the response to a batch request.
+type BatchResponse struct {
+	Results []BatchResult `json:"results,omitempty"`
+}
+
+type batchProcessor func(filePath string, content io.Reader, parameters string) BatchResult
+
+func isBatchRequest(request *http.Request) bool {
+	return request.FormValue(BatchField) == "true"
+}
+
+// handleBatch processes every file of a parsed batch request, writes the results and returns the status code.
+func handleBatch(writer http.ResponseWriter, request *http.Request, process batchProcessor) int {
+	filePaths := make([]string, 0, len(request.MultipartForm.File))
+	for filePath := range request.MultipartForm.File {
+		filePaths = append(filePaths, filePath)
+	}
+	if len(filePaths) == 0 {
+		http.Error(writer, "Batch request without files", http.StatusBadRequest)
+		return http.StatusBadRequest
+	}
+	sort.Strings(filePaths)
+
+	parameters := request.FormValue("parameters")
+	response := BatchResponse{Results: make([]BatchResult, 0, len(filePaths))}
+	for _, filePath := range filePaths {
+		result, err := processBatchFile(filePath, request, parameters, process)
+		if err != nil {
+			log.Error(errors.Wrapf(err, "while accessing the content of %s", filePath))
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return http.StatusBadRequest
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		log.Error(errors.Wrap(err, "while writing the batch response"))
+	}
+
+	return http.StatusOK
+}
+
+func processBatchFile(filePath string, request *http.Request, parameters string, process batchProcessor) (BatchResult, error) {
+	content, _, err := request.FormFile(filePath)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	defer content.Close()
+
+	result := process(filePath, content, parameters)
+	result.FilePath = filePath
+
+	return result, nil
+}
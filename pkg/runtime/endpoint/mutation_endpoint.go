@@ -74,6 +74,15 @@ func (e *mutationEndpoint) Handle(writer http.ResponseWriter, request *http.Requ
 	}
 	defer request.MultipartForm.RemoveAll()
 
+	if isBatchRequest(request) {
+		status := handleBatch(writer, request, e.mutateBatchFile(request))
+		incrementMutationStatusCodeCounter(status)
+		if status == http.StatusOK {
+			httpServeAndMutationHistogram.Observe(time.Since(start).Seconds())
+		}
+		return
+	}
+
 	content, _, err := request.FormFile("content")
 	if err != nil {
 		log.Error(errors.Wrap(err, "while accessing the content"))
@@ -105,3 +114,18 @@ func (e *mutationEndpoint) Handle(writer http.ResponseWriter, request *http.Requ
 
 	httpServeAndMutationHistogram.Observe(time.Since(start).Seconds())
 }
+
+func (e *mutationEndpoint) mutateBatchFile(request *http.Request) batchProcessor {
+	return func(filePath string, content io.Reader, parameters string) BatchResult {
+		result, modified, err := e.mutator.Mutate(request.Context(), content, parameters)
+		if err != nil {
+			log.Error(errors.Wrapf(err, "while mutating %s", filePath))
+			return BatchResult{Message: err.Error()}
+		}
+		if !modified {
+			return BatchResult{Success: true}
+		}
+
+		return BatchResult{Success: true, Modified: true, Content: result}
+	}
+}
@@ -3,6 +3,7 @@ package endpoint_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -100,6 +101,30 @@ func TestMutationEndpoint_Handle_NoMultipartRequest(t *testing.T) {
 	g.Expect(recorder.Result().StatusCode).To(gomega.Equal(http.StatusBadRequest))
 }
 
+func TestMutationEndpoint_Handle_Batch(t *testing.T) {
+	// given
+	g := gomega.NewWithT(t)
+	edp := endpoint.NewMutation("test", &fakeMutator{message: "mutated"})
+	body, contentType, err := fake.BatchRequestBodyFromFiles([]string{"./mutation_endpoint.go", "./validation_endpoint.go"}, "{\"test\":\"\"}")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/test", body)
+	request.Header.Add("Content-Type", contentType)
+
+	// when
+	http.HandlerFunc(edp.Handle).ServeHTTP(recorder, request)
+
+	// then
+	g.Expect(recorder.Result().StatusCode).To(gomega.Equal(http.StatusOK))
+	response := endpoint.BatchResponse{}
+	g.Expect(json.NewDecoder(recorder.Result().Body).Decode(&response)).To(gomega.Succeed())
+	g.Expect(response.Results).To(gomega.Equal([]endpoint.BatchResult{
+		{FilePath: "./mutation_endpoint.go", Success: true, Modified: true, Content: []byte("mutated")},
+		{FilePath: "./validation_endpoint.go", Success: true, Modified: true, Content: []byte("mutated")},
+	}))
+}
+
 var _ endpoint.Mutator = &fakeMutator{}
 
 type fakeMutator struct {
@@ -74,6 +74,15 @@ func (e *validationEndpoint) Handle(writer http.ResponseWriter, request *http.Re
 	}
 	defer request.MultipartForm.RemoveAll()
 
+	if isBatchRequest(request) {
+		status := handleBatch(writer, request, e.validateBatchFile(request))
+		incrementValidationStatusCounter(status)
+		if status == http.StatusOK {
+			httpServeAnValidationHistogram.Observe(time.Since(start).Seconds())
+		}
+		return
+	}
+
 	content, _, err := request.FormFile("content")
 	if err != nil {
 		log.Error(errors.Wrap(err, "while accessing the content"))
@@ -97,3 +106,14 @@ func (e *validationEndpoint) Handle(writer http.ResponseWriter, request *http.Re
 
 	httpServeAnValidationHistogram.Observe(time.Since(start).Seconds())
 }
+
+func (e *validationEndpoint) validateBatchFile(request *http.Request) batchProcessor {
+	return func(filePath string, content io.Reader, parameters string) BatchResult {
+		if err := e.validator.Validate(request.Context(), content, parameters); err != nil {
+			log.Error(errors.Wrapf(err, "while validating %s", filePath))
+			return BatchResult{Message: err.Error()}
+		}
+
+		return BatchResult{Success: true}
+	}
+}
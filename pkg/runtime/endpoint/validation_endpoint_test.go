@@ -2,10 +2,13 @@ package endpoint_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/rafter/pkg/runtime/endpoint"
@@ -84,6 +87,50 @@ func TestValidationEndpoint_Handle_NoMultipartRequest(t *testing.T) {
 	g.Expect(recorder.Result().StatusCode).To(gomega.Equal(http.StatusBadRequest))
 }
 
+func TestValidationEndpoint_Handle_Batch(t *testing.T) {
+	t.Run("Results", func(t *testing.T) {
+		// given
+		g := gomega.NewWithT(t)
+		edp := endpoint.NewValidation("test", &contentValidator{reject: "mutator Mutator"})
+		body, contentType, err := fake.BatchRequestBodyFromFiles([]string{"./validation_endpoint.go", "./mutation_endpoint.go"}, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/test", body)
+		request.Header.Add("Content-Type", contentType)
+
+		// when
+		http.HandlerFunc(edp.Handle).ServeHTTP(recorder, request)
+
+		// then
+		g.Expect(recorder.Result().StatusCode).To(gomega.Equal(http.StatusOK))
+		response := endpoint.BatchResponse{}
+		g.Expect(json.NewDecoder(recorder.Result().Body).Decode(&response)).To(gomega.Succeed())
+		g.Expect(response.Results).To(gomega.Equal([]endpoint.BatchResult{
+			{FilePath: "./mutation_endpoint.go", Success: false, Message: "rejected"},
+			{FilePath: "./validation_endpoint.go", Success: true},
+		}))
+	})
+
+	t.Run("NoFiles", func(t *testing.T) {
+		// given
+		g := gomega.NewWithT(t)
+		edp := endpoint.NewValidation("test", &fakeValidator{})
+		body, contentType, err := fake.BatchRequestBodyFromFiles(nil, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/test", body)
+		request.Header.Add("Content-Type", contentType)
+
+		// when
+		http.HandlerFunc(edp.Handle).ServeHTTP(recorder, request)
+
+		// then
+		g.Expect(recorder.Result().StatusCode).To(gomega.Equal(http.StatusBadRequest))
+	})
+}
+
 var _ endpoint.Validator = &fakeValidator{}
 
 type fakeValidator struct {
@@ -97,3 +144,22 @@ func (v *fakeValidator) Validate(ctx context.Context, reader io.Reader, metadata
 
 	return nil
 }
+
+var _ endpoint.Validator = &contentValidator{}
+
+// contentValidator rejects files containing the given text
+type contentValidator struct {
+	reject string
+}
+
+func (v *contentValidator) Validate(ctx context.Context, reader io.Reader, metadata string) error {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(string(content), v.reject) {
+		return errors.New("rejected")
+	}
+
+	return nil
+}
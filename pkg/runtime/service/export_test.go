@@ -2,10 +2,11 @@ package service
 
 import "net/http"
 
-func NewTestService(config Config) *service {
+func NewTestService(config Config, verifiers ...Verifier) *service {
 	return &service{
-		host: config.Host,
-		port: config.Port,
+		verifiers: verifiers,
+		host:      config.Host,
+		port:      config.Port,
 	}
 }
 
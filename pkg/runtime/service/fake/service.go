@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/kyma-project/rafter/pkg/runtime/endpoint"
 	"github.com/kyma-project/rafter/pkg/runtime/service"
 	log "github.com/sirupsen/logrus"
 )
@@ -63,6 +65,40 @@ func RequestBodyFromFile(filePath, parameters string) (io.Reader, string, error)
 	return buffer, formWriter.FormDataContentType(), nil
 }
 
+// BatchRequestBodyFromFiles builds a multipart batch request from files. The files are sent in parts named by their paths.
+func BatchRequestBodyFromFiles(filePaths []string, parameters string) (io.Reader, string, error) {
+	buffer := &bytes.Buffer{}
+	formWriter := multipart.NewWriter(buffer)
+	defer formWriter.Close()
+
+	for _, filePath := range filePaths {
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "while reading the file %s", filePath)
+		}
+
+		contentWriter, err := formWriter.CreateFormFile(filePath, filepath.Base(filePath))
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "while creating the field for the file %s", filePath)
+		}
+
+		if _, err := contentWriter.Write(content); err != nil {
+			return nil, "", errors.Wrapf(err, "while writing the file %s to its field", filePath)
+		}
+	}
+
+	if err := formWriter.WriteField(endpoint.BatchField, "true"); err != nil {
+		return nil, "", errors.Wrap(err, "while creating the batch field")
+	}
+	if parameters != "" {
+		if err := formWriter.WriteField("parameters", parameters); err != nil {
+			return nil, "", errors.Wrapf(err, "while creating the parameters field for parameters %s", parameters)
+		}
+	}
+
+	return buffer, formWriter.FormDataContentType(), nil
+}
+
 // ServeHTTP dispatches the request to the handler that
 // most closely matches the request URL in its pattern.
 func (s *Service) ServeHTTP(method, endpoint, contentType string, body io.Reader) *http.Response {
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 )
@@ -13,6 +17,11 @@ import (
 type Config struct {
 	Host string `envconfig:"default=127.0.0.1"`
 	Port int    `envconfig:"default=3000"`
+	// TLSCertFile and TLSKeyFile enable HTTPS.
+	TLSCertFile string `envconfig:"optional"`
+	TLSKeyFile  string `envconfig:"optional"`
+	// ClientCAFile enables verification of client certificates. It requires HTTPS.
+	ClientCAFile string `envconfig:"optional"`
 }
 
 // Service is the interface implemented by Asset Store services.
@@ -28,18 +37,27 @@ type HTTPEndpoint interface {
 }
 
 type service struct {
-	endpoints []HTTPEndpoint
-	host      string
-	port      int
+	endpoints    []HTTPEndpoint
+	verifiers    []Verifier
+	host         string
+	port         int
+	tlsCertFile  string
+	tlsKeyFile   string
+	clientCAFile string
 }
 
 var _ Service = &service{}
 
 // New is the constructor that creates a new Asset Store service.
-func New(config Config) Service {
+// Endpoints accept only requests authenticated by any of the verifiers, if any are given.
+func New(config Config, verifiers ...Verifier) Service {
 	return &service{
-		host: config.Host,
-		port: config.Port,
+		verifiers:    verifiers,
+		host:         config.Host,
+		port:         config.Port,
+		tlsCertFile:  config.TLSCertFile,
+		tlsKeyFile:   config.TLSKeyFile,
+		clientCAFile: config.ClientCAFile,
 	}
 }
 
@@ -52,7 +70,7 @@ func (s *service) setupHandlers() *http.ServeMux {
 		}
 		log.Infof("Registering %s endpoint", endpoint.Name())
 		path := fmt.Sprintf("/%s", endpoint.Name())
-		mux.HandleFunc(path, endpoint.Handle)
+		mux.HandleFunc(path, authenticate(s.verifiers, endpoint.Handle))
 	}
 	log.Info("Registering metrics endpoint")
 	mux.Handle("/metrics", promhttp.Handler())
@@ -66,11 +84,22 @@ func (s *service) Start(ctx context.Context) error {
 
 	host := fmt.Sprintf("%s:%d", s.host, s.port)
 
-	srv := &http.Server{Addr: host, Handler: mux}
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return errors.Wrap(err, "while configuring TLS")
+	}
+
+	srv := &http.Server{Addr: host, Handler: mux, TLSConfig: tlsConfig}
 	log.Infof("Service listen at %s", host)
 
 	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.tlsCertFile != "" {
+			err = srv.ListenAndServeTLS(s.tlsCertFile, s.tlsKeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Error while starting HTTP service: %v", err)
 		}
 	}()
@@ -79,6 +108,29 @@ func (s *service) Start(ctx context.Context) error {
 	return srv.Shutdown(context.Background())
 }
 
+func (s *service) tlsConfig() (*tls.Config, error) {
+	if s.clientCAFile == "" {
+		return nil, nil
+	}
+	if s.tlsCertFile == "" {
+		return nil, errors.New("client certificates can't be verified without HTTPS")
+	}
+
+	caBundle, err := ioutil.ReadFile(s.clientCAFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading client CA file %s", s.clientCAFile)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, errors.Errorf("client CA file %s doesn't contain any valid certificate", s.clientCAFile)
+	}
+
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}, nil
+}
+
 // Register adds an endpoint to a service.
 func (s *service) Register(endpoint HTTPEndpoint) {
 	s.endpoints = append(s.endpoints, endpoint)
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+// AuthenticationConfig is used to customize the verification of callers.
+type AuthenticationConfig struct {
+	Enabled                bool   `envconfig:"default=false"`
+	AllowedClientNames     string `envconfig:"optional"`
+	AllowedServiceAccounts string `envconfig:"default=kyma-system:rafter-controller-manager"`
+	TokenAudiences         string `envconfig:"optional"`
+}
+
+// Verifier is the interface implemented by request authenticators.
+type Verifier interface {
+	Verify(request *http.Request) error
+}
+
+// TokenReviewer is the interface implemented by the Kubernetes TokenReview client.
+type TokenReviewer interface {
+	Create(tokenReview *authenticationv1.TokenReview) (*authenticationv1.TokenReview, error)
+}
+
+type certificateVerifier struct {
+	allowedNames map[string]struct{}
+}
+
+// NewCertificateVerifier is the constructor that creates a verifier accepting requests with a verified client certificate.
+// The common name of the certificate must be one of the allowed names, if any are given.
+func NewCertificateVerifier(allowedNames ...string) Verifier {
+	return &certificateVerifier{allowedNames: toSet(allowedNames)}
+}
+
+func (v *certificateVerifier) Verify(request *http.Request) error {
+	if request.TLS == nil || len(request.TLS.VerifiedChains) == 0 || len(request.TLS.VerifiedChains[0]) == 0 {
+		return errors.New("missing verified client certificate")
+	}
+
+	name := request.TLS.VerifiedChains[0][0].Subject.CommonName
+	if len(v.allowedNames) > 0 && !contains(v.allowedNames, name) {
+		return errors.Errorf("client certificate %s is not allowed", name)
+	}
+
+	return nil
+}
+
+type tokenVerifier struct {
+	reviewer     TokenReviewer
+	audiences    []string
+	allowedUsers map[string]struct{}
+}
+
+// NewTokenVerifier is the constructor that creates a verifier accepting requests with a bearer token confirmed by the TokenReview API.
+// The user of the token must be one of the allowed users, if any are given.
+func NewTokenVerifier(reviewer TokenReviewer, audiences []string, allowedUsers ...string) Verifier {
+	return &tokenVerifier{
+		reviewer:     reviewer,
+		audiences:    audiences,
+		allowedUsers: toSet(allowedUsers),
+	}
+}
+
+func (v *tokenVerifier) Verify(request *http.Request) error {
+	header := request.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return errors.New("missing bearer token")
+	}
+
+	review, err := v.reviewer.Create(&authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     strings.TrimPrefix(header, "Bearer "),
+			Audiences: v.audiences,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "while reviewing token")
+	}
+	if !review.Status.Authenticated {
+		return errors.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+
+	user := review.Status.User.Username
+	if len(v.allowedUsers) > 0 && !contains(v.allowedUsers, user) {
+		return errors.Errorf("user %s is not allowed", user)
+	}
+
+	return nil
+}
+
+// NewVerifiers is the constructor that creates verifiers from the configuration. No verifiers are returned if authentication is disabled.
+// Token audiences are required, so that only tokens issued for webhooks are accepted and not the ones valid for the API server.
+func NewVerifiers(config AuthenticationConfig, reviewer TokenReviewer) ([]Verifier, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	audiences := splitList(config.TokenAudiences)
+	if len(audiences) == 0 {
+		return nil, errors.New("token audiences are required when authentication is enabled")
+	}
+
+	var users []string
+	for _, serviceAccount := range splitList(config.AllowedServiceAccounts) {
+		users = append(users, "system:serviceaccount:"+serviceAccount)
+	}
+
+	return []Verifier{
+		NewCertificateVerifier(splitList(config.AllowedClientNames)...),
+		NewTokenVerifier(reviewer, audiences, users...),
+	}, nil
+}
+
+// authenticate passes requests accepted by any of the verifiers to the handler.
+func authenticate(verifiers []Verifier, handler http.HandlerFunc) http.HandlerFunc {
+	if len(verifiers) == 0 {
+		return handler
+	}
+
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var messages []string
+		for _, verifier := range verifiers {
+			err := verifier.Verify(request)
+			if err == nil {
+				handler(writer, request)
+				return
+			}
+			messages = append(messages, err.Error())
+		}
+
+		log.Warnf("Rejecting unauthenticated request to %s: %s", request.URL.Path, strings.Join(messages, ", "))
+		http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	}
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func toSet(items []string) map[string]struct{} {
+	result := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		result[item] = struct{}{}
+	}
+
+	return result
+}
+
+func contains(set map[string]struct{}, item string) bool {
+	_, ok := set[item]
+	return ok
+}
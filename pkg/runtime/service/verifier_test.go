@@ -0,0 +1,213 @@
+package service_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-project/rafter/pkg/runtime/service"
+	"github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func TestCertificateVerifier_Verify(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		state        *tls.ConnectionState
+		allowedNames []string
+		expectErr    bool
+	}{
+		"no TLS": {
+			expectErr: true,
+		},
+		"no client certificate": {
+			state:     &tls.ConnectionState{},
+			expectErr: true,
+		},
+		"any name": {
+			state: fixConnectionState("rafter-controller-manager"),
+		},
+		"allowed name": {
+			state:        fixConnectionState("rafter-controller-manager"),
+			allowedNames: []string{"rafter-controller-manager"},
+		},
+		"not allowed name": {
+			state:        fixConnectionState("other"),
+			allowedNames: []string{"rafter-controller-manager"},
+			expectErr:    true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// given
+			g := gomega.NewWithT(t)
+			verifier := service.NewCertificateVerifier(testCase.allowedNames...)
+			request := httptest.NewRequest(http.MethodPost, "/test", nil)
+			request.TLS = testCase.state
+
+			// when
+			err := verifier.Verify(request)
+
+			// then
+			if testCase.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+		})
+	}
+}
+
+func TestTokenVerifier_Verify(t *testing.T) {
+	const allowedUser = "system:serviceaccount:kyma-system:rafter-controller-manager"
+
+	for testName, testCase := range map[string]struct {
+		header    string
+		reviewer  *testReviewer
+		expectErr bool
+	}{
+		"missing token": {
+			reviewer:  &testReviewer{},
+			expectErr: true,
+		},
+		"authenticated": {
+			header:   "Bearer token",
+			reviewer: &testReviewer{authenticated: true, user: allowedUser},
+		},
+		"not authenticated": {
+			header:    "Bearer token",
+			reviewer:  &testReviewer{},
+			expectErr: true,
+		},
+		"not allowed user": {
+			header:    "Bearer token",
+			reviewer:  &testReviewer{authenticated: true, user: "system:serviceaccount:default:other"},
+			expectErr: true,
+		},
+		"review error": {
+			header:    "Bearer token",
+			reviewer:  &testReviewer{err: errors.New("test")},
+			expectErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// given
+			g := gomega.NewWithT(t)
+			verifier := service.NewTokenVerifier(testCase.reviewer, []string{"rafter"}, allowedUser)
+			request := httptest.NewRequest(http.MethodPost, "/test", nil)
+			if testCase.header != "" {
+				request.Header.Set("Authorization", testCase.header)
+			}
+
+			// when
+			err := verifier.Verify(request)
+
+			// then
+			if testCase.expectErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(testCase.reviewer.token).To(gomega.Equal("token"))
+			g.Expect(testCase.reviewer.audiences).To(gomega.ConsistOf("rafter"))
+		})
+	}
+}
+
+func TestService_setupHandlers_Authentication(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		header         string
+		expectedStatus int
+	}{
+		"unauthenticated": {
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"authenticated": {
+			header:         "Bearer token",
+			expectedStatus: http.StatusOK,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			// given
+			g := gomega.NewWithT(t)
+			reviewer := &testReviewer{authenticated: true, user: "system:serviceaccount:kyma-system:rafter-controller-manager"}
+			verifiers, err := service.NewVerifiers(service.AuthenticationConfig{
+				Enabled:                true,
+				AllowedServiceAccounts: "kyma-system:rafter-controller-manager",
+				TokenAudiences:         "rafter-webhooks",
+			}, reviewer)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			srv := service.NewTestService(service.Config{}, verifiers...)
+			srv.Register(fixEndpoint("test", http.StatusOK))
+			mux := srv.SetupHandlers()
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/test", nil)
+			if testCase.header != "" {
+				request.Header.Set("Authorization", testCase.header)
+			}
+			metRecorder := httptest.NewRecorder()
+			metricsReq := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+
+			// when
+			mux.ServeHTTP(recorder, request)
+			mux.ServeHTTP(metRecorder, metricsReq)
+
+			// then
+			g.Expect(recorder.Result().StatusCode).To(gomega.Equal(testCase.expectedStatus))
+			g.Expect(metRecorder.Result().StatusCode).To(gomega.Equal(http.StatusOK))
+		})
+	}
+}
+
+func TestNewVerifiers_Disabled(t *testing.T) {
+	// given
+	g := gomega.NewWithT(t)
+
+	// when
+	verifiers, err := service.NewVerifiers(service.AuthenticationConfig{}, &testReviewer{})
+
+	// then
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(verifiers).To(gomega.BeEmpty())
+}
+
+func TestNewVerifiers_MissingAudiences(t *testing.T) {
+	// given
+	g := gomega.NewWithT(t)
+
+	// when
+	_, err := service.NewVerifiers(service.AuthenticationConfig{Enabled: true}, &testReviewer{})
+
+	// then
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func fixConnectionState(commonName string) *tls.ConnectionState {
+	return &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: commonName}}}},
+	}
+}
+
+type testReviewer struct {
+	authenticated bool
+	user          string
+	err           error
+	token         string
+	audiences     []string
+}
+
+func (r *testReviewer) Create(tokenReview *authenticationv1.TokenReview) (*authenticationv1.TokenReview, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.token = tokenReview.Spec.Token
+	r.audiences = tokenReview.Spec.Audiences
+
+	result := tokenReview.DeepCopy()
+	result.Status.Authenticated = r.authenticated
+	result.Status.User.Username = r.user
+	return result, nil
+}